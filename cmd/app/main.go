@@ -1,20 +1,120 @@
 package main
 
 import (
-    "log"
+    "context"
+    "errors"
+    "flag"
+    "fmt"
+    "log/slog"
+    "os"
+    "os/signal"
+    "strings"
+    "syscall"
+    _ "time/tzdata"
 
     "clicker/internal/app"
     "clicker/internal/config"
+    "clicker/internal/logging"
 )
 
+const usage = `Usage:
+  clicks-counter [flags]                       run the service
+  clicks-counter config print [flags]          print the effective configuration
+` + app.MigrateUsage + `
+
+Run "clicks-counter -h" for the flags.`
+
 func main() {
-    cfg, err := config.New()
+    args := os.Args[1:]
+    if len(args) > 0 && args[0] == "config" {
+        os.Exit(configCommand(args[1:]))
+    }
+    if len(args) > 0 && args[0] == "migrate" {
+        os.Exit(migrateCommand(args[1:]))
+    }
+
+    cfg, err := config.Load(args)
+    if errors.Is(err, flag.ErrHelp) {
+        fmt.Fprintln(os.Stderr, usage)
+        return
+    }
+    if err != nil {
+        slog.Error("Failed to load config", "error", err)
+        os.Exit(1)
+    }
+
+    logger, err := logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
     if err != nil {
-        log.Fatalf("Failed to load config: %v", err)
+        slog.Error("Invalid logging settings", "error", err)
+        os.Exit(1)
     }
+    slog.SetDefault(logger)
 
-    app := app.New(cfg)
+    app, err := app.New(cfg, logger)
+    if err != nil {
+        logger.Error("Failed to set up application", "error", err)
+        os.Exit(1)
+    }
     if err := app.Run(); err != nil {
-        log.Fatalf("Application error: %v", err)
+        logger.Error("Application error", "error", err)
+        os.Exit(1)
+    }
+}
+
+// configCommand runs "clicks-counter config print", which loads the configuration
+// like the service does and prints it.
+func configCommand(args []string) int {
+    if len(args) == 0 || args[0] != "print" {
+        fmt.Fprintln(os.Stderr, usage)
+        return 2
+    }
+
+    cfg, err := config.Load(args[1:])
+    if errors.Is(err, flag.ErrHelp) {
+        return 0
+    }
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+        return 1
+    }
+    if err := cfg.Print(os.Stdout); err != nil {
+        fmt.Fprintf(os.Stderr, "Failed to print configuration: %v\n", err)
+        return 1
+    }
+    return 0
+}
+
+// migrateCommand runs "clicks-counter migrate <action> [argument] [flags]".
+func migrateCommand(args []string) int {
+    if len(args) == 0 {
+        fmt.Fprintln(os.Stderr, usage)
+        return 2
+    }
+    action, args := args[0], args[1:]
+    var arg string
+    if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+        arg, args = args[0], args[1:]
+    }
+
+    cfg, err := config.Load(args)
+    if errors.Is(err, flag.ErrHelp) {
+        return 0
+    }
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+        return 1
+    }
+    logger, err := logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Invalid logging settings: %v\n", err)
+        return 1
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
+    if err := app.Migrate(ctx, cfg, logger, action, arg, os.Stdout); err != nil {
+        logger.Error("Migration failed", "error", err)
+        return 1
     }
+    return 0
 }
@@ -2,136 +2,709 @@ package app
 
 import (
     "context"
+    "errors"
     "github.com/jackc/pgx/v4/pgxpool"
     "fmt"
-    "log"
+    "log/slog"
     "net"
     "net/http"
-    "os"
     "os/signal"
+    "strconv"
+    "strings"
+    "sync/atomic"
     "syscall"
     "time"
 
+    "clicker/internal/application/filter"
     "clicker/internal/application/usecase"
+    "clicker/internal/auth"
+    "clicker/internal/clicktoken"
+    "clicker/internal/clientip"
     "clicker/internal/config"
+    "clicker/internal/domain/entity"
     "clicker/internal/domain/repository"
+    "clicker/internal/health"
     "clicker/internal/interfaces/grpc/handler"
+    "clicker/internal/interfaces/grpc/interceptor"
+    "clicker/internal/interfaces/httpapi"
+    "clicker/internal/logging"
+    "clicker/internal/metrics"
+    "clicker/internal/supervisor"
+    "clicker/internal/tlsconfig"
+    "clicker/internal/tracing"
+    "clicker/internal/webhook"
+    "clicker/pkg/admin"
+    "clicker/pkg/alert"
+    "clicker/pkg/apikey"
+    "clicker/pkg/banner"
+    "clicker/pkg/campaign"
+    "clicker/pkg/conversion"
     "clicker/pkg/counter"
+    "clicker/pkg/impression"
     "clicker/pkg/stats"
 
     "github.com/gorilla/mux"
+    "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
     "google.golang.org/grpc"
     "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+    "google.golang.org/grpc/credentials"
     "google.golang.org/grpc/credentials/insecure"
+    grpchealth "google.golang.org/grpc/health"
+    healthpb "google.golang.org/grpc/health/grpc_health_v1"
     _ "github.com/lib/pq"
 )
 
+// certReloadInterval is how often certificate files are checked for changes.
+const certReloadInterval = 30 * time.Second
+
+// healthCheckInterval is how often the health checks run.
+const healthCheckInterval = 5 * time.Second
+
 type App struct {
     cfg    *config.Config
+    mode   mode
+    logger *slog.Logger
     router *mux.Router
     grpc   *grpc.Server
     db     *pgxpool.Pool
+    // readDB is the pool of the read replica, or db without one.
+    readDB *pgxpool.Pool
+    tls    *tlsMaterial
+    health *health.Checker
+
+    // dbReady is set once the database answered and is migrated.
+    dbReady     *atomic.Bool
+    connect     connectPolicy
+    autoMigrate bool
+
+    alerts        repository.AlertUseCase
+    alertInterval time.Duration
+    capEvents     *usecase.CapEventRelay
+
+    batchers        []repository.BatchControl
+    shutdownTimeout time.Duration
+    shutdownTracing func(context.Context) error
 }
 
-func New(cfg *config.Config) *App {
-    dbConfig, err := pgxpool.ParseConfig(cfg.GetPostgresDSN())
+// New wires the parts of the service the mode runs. cfg must have passed
+// Validate, as it has when it comes from config.Load, so the settings that
+// Validate parses in full are not checked again here.
+func New(cfg *config.Config, logger *slog.Logger) (*App, error) {
+    sampleRatio, err := strconv.ParseFloat(cfg.TracingSampleRatio, 64)
+    if err != nil || sampleRatio < 0 || sampleRatio > 1 {
+        return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATIO: %q", cfg.TracingSampleRatio)
+    }
+    shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+        Exporter:    cfg.TracingExporter,
+        File:        cfg.TracingFile,
+        SampleRatio: sampleRatio,
+    })
     if err != nil {
-        log.Fatalf("Unable to parse PostgreSQL DSN: %v", err)
+        return nil, fmt.Errorf("unable to set up tracing: %w", err)
     }
 
-    db, err := pgxpool.ConnectConfig(context.Background(), dbConfig)
+    db, err := newPool(cfg.GetPostgresDSN(), cfg)
     if err != nil {
-        log.Fatalf("Unable to connect to database: %v", err)
+        return nil, err
     }
 
-    grpcServer := grpc.NewServer()
+    connect, err := newConnectPolicy(cfg)
+    if err != nil {
+        return nil, err
+    }
+    autoMigrate, err := strconv.ParseBool(cfg.AutoMigrate)
+    if err != nil {
+        return nil, fmt.Errorf("invalid AUTO_MIGRATE: %q", cfg.AutoMigrate)
+    }
+    dbReady := new(atomic.Bool)
+
+    shutdownTimeout, err := time.ParseDuration(cfg.ShutdownTimeout)
+    if err != nil || shutdownTimeout <= 0 {
+        return nil, fmt.Errorf("invalid SHUTDOWN_TIMEOUT: %q", cfg.ShutdownTimeout)
+    }
+
+    metrics.RegisterPool(db)
+    bannerLimit, err := strconv.Atoi(cfg.MetricsBannerLimit)
+    if err != nil || bannerLimit < 0 {
+        return nil, fmt.Errorf("invalid METRICS_BANNER_LIMIT: %q", cfg.MetricsBannerLimit)
+    }
+    metrics.SetBannerLimit(bannerLimit)
+
+    trustedProxies, _ := clientip.ParseTrustedProxies(cfg.TrustedProxies)
+    clientip.SetTrustedProxies(trustedProxies)
+
+    m := mode(cfg.Mode)
+    if !m.ingests() && !m.queries() && !m.works() {
+        return nil, fmt.Errorf("invalid MODE: %q", cfg.Mode)
+    }
+
+    // Stats queries and alert evaluation only read, so they can go to a
+    // replica; everything else needs the primary.
+    readDB := db
+    var replicaCheck health.Func
+    if cfg.PostgresReadDSN != "" && (m.queries() || m.works()) {
+        readDB, err = newPool(cfg.PostgresReadDSN, cfg)
+        if err != nil {
+            return nil, fmt.Errorf("invalid POSTGRES_READ_DSN: %w", err)
+        }
+        replicaCheck = replicaDatabaseCheck(readDB)
+    }
 
     clickRepo := repository.NewPostgresClickRepository(db)
-    statsRepo := repository.NewPostgresStatsRepository(db)
+    statsRepo := repository.NewPostgresStatsRepository(readDB)
+    campaignRepo := repository.NewPostgresCampaignRepository(db)
+    bannerRepo := repository.NewPostgresBannerRepository(db)
+    apiKeyRepo := repository.NewPostgresAPIKeyRepository(db)
+    sketchRepo := repository.NewPostgresSketchRepository(db)
+    readSketchRepo := repository.NewPostgresSketchRepository(readDB)
+    impressionRepo := repository.NewPostgresImpressionRepository(db)
+    conversionRepo := repository.NewPostgresConversionRepository(db)
+    alertRepo := repository.NewPostgresAlertRepository(db)
+
+    // Cap events reach the hub of every query node through the database,
+    // wherever the click reaching the cap was counted.
+    capEvents := usecase.NewCapEventHub()
+    capRelay := usecase.NewCapEventRelay(repository.NewPostgresCapEventBus(db), capEvents, logger)
+    redirectDomains := entity.DomainAllowlist(strings.Split(cfg.RedirectAllowedDomains, ","))
+
+    var jwtVerifier *auth.JWTVerifier
+    if cfg.JWKSFile != "" {
+        keys, err := auth.LoadJWKS(cfg.JWKSFile)
+        if err != nil {
+            return nil, fmt.Errorf("unable to load JWKS: %w", err)
+        }
+        jwtVerifier = auth.NewJWTVerifier(keys, cfg.JWTIssuer, cfg.JWTAudience)
+    }
+    authUseCase := usecase.NewAuthUseCase(apiKeyRepo, jwtVerifier, cfg.OperatorToken)
+
+    // What the mode leaves out stays nil: its services are not registered
+    // and its goroutines never start.
+    var (
+        clickUseCase      repository.ClickUseCase
+        impressionUseCase repository.ImpressionUseCase
+        redirectUseCase   repository.RedirectUseCase
+        conversionUseCase repository.ConversionUseCase
+        alertUseCase      repository.AlertUseCase
+        alertInterval     time.Duration
+        batchers          []repository.BatchControl
+
+        clickHandler      *handler.ClickHandler
+        impressionHandler *handler.ImpressionHandler
+        conversionHandler *handler.ConversionHandler
+        adminHandler      *handler.AdminHandler
+        statsHandler      *handler.StatsHandler
+        campaignHandler   *handler.CampaignHandler
+        bannerHandler     *handler.BannerHandler
+        apiKeyHandler     *handler.APIKeyHandler
+        alertHandler      *handler.AlertHandler
+    )
+
+    if m.ingests() {
+        clickTokens, err := newClickTokenSigner(cfg)
+        if err != nil {
+            return nil, fmt.Errorf("invalid click token settings: %w", err)
+        }
+
+        clickFilter, err := newClickFilter(cfg)
+        if err != nil {
+            return nil, fmt.Errorf("invalid click filter settings: %w", err)
+        }
+
+        batchConfig, err := newBatchConfig(cfg)
+        if err != nil {
+            return nil, fmt.Errorf("invalid batch settings: %w", err)
+        }
+
+        conversionWindow, err := time.ParseDuration(cfg.ConversionWindow)
+        if err != nil {
+            return nil, fmt.Errorf("invalid CONVERSION_ATTRIBUTION_WINDOW: %w", err)
+        }
+
+        clickUseCase = usecase.NewClickUseCase(clickRepo, sketchRepo, bannerRepo, capRelay, usecase.FlightPolicy(cfg.FlightPolicy),
+            clickTokens, clickFilter, batchConfig, logger)
+        impressionUseCase = usecase.NewImpressionUseCase(impressionRepo, bannerRepo, batchConfig, logger)
+        redirectUseCase = usecase.NewRedirectUseCase(clickUseCase, bannerRepo, redirectDomains, logger)
+        conversionUseCase = usecase.NewConversionUseCase(conversionRepo, clickRepo, conversionWindow)
+        batchers = []repository.BatchControl{clickUseCase, impressionUseCase}
 
-    clickUseCase := usecase.NewClickUseCase(clickRepo)
-    statsUseCase := usecase.NewStatsUseCase(statsRepo)
+        clickHandler = handler.NewClickHandler(clickUseCase)
+        impressionHandler = handler.NewImpressionHandler(impressionUseCase)
+        conversionHandler = handler.NewConversionHandler(conversionUseCase)
+        adminHandler = handler.NewAdminHandler(usecase.NewAdminUseCase(batchers...))
+    }
+
+    if m.queries() || m.works() {
+        alertInterval, err = time.ParseDuration(cfg.AlertInterval)
+        if err != nil || alertInterval <= 0 {
+            return nil, fmt.Errorf("invalid ALERT_EVALUATION_INTERVAL: %q", cfg.AlertInterval)
+        }
+        alertRetries, err := strconv.Atoi(cfg.AlertWebhookRetries)
+        if err != nil || alertRetries < 0 {
+            return nil, fmt.Errorf("invalid ALERT_WEBHOOK_RETRIES: %q", cfg.AlertWebhookRetries)
+        }
+        alertUseCase = usecase.NewAlertUseCase(alertRepo, statsRepo,
+            webhook.NewSender([]byte(cfg.AlertWebhookSecret), alertRetries), logger)
+    }
 
-    clickHandler := handler.NewClickHandler(clickUseCase)
-    statsHandler := handler.NewStatsHandler(statsUseCase)
+    if m.queries() {
+        statsHandler = handler.NewStatsHandler(usecase.NewStatsUseCase(statsRepo, readSketchRepo))
+        campaignHandler = handler.NewCampaignHandler(usecase.NewCampaignUseCase(campaignRepo, readSketchRepo))
+        bannerHandler = handler.NewBannerHandler(usecase.NewBannerUseCase(bannerRepo, capEvents, redirectDomains))
+        apiKeyHandler = handler.NewAPIKeyHandler(usecase.NewAPIKeyUseCase(apiKeyRepo))
+        alertHandler = handler.NewAlertHandler(alertUseCase)
+    }
 
-    grpcHandler := handler.NewHandler(clickHandler, statsHandler)
+    rateLimits, _ := interceptor.ParseRateLimits(cfg.RateLimits)
+    loggingInterceptor := interceptor.NewLogging(logger)
+    metricsInterceptor := interceptor.NewMetrics()
+    authInterceptor := interceptor.NewAuth(authUseCase)
+    rateLimitInterceptor := interceptor.NewRateLimit(rateLimits)
+
+    serverOpts := []grpc.ServerOption{
+        grpc.StatsHandler(otelgrpc.NewServerHandler()),
+        grpc.ChainUnaryInterceptor(loggingInterceptor.Unary(), metricsInterceptor.Unary(),
+            rateLimitInterceptor.UnaryByIP(), authInterceptor.Unary(), rateLimitInterceptor.Unary()),
+        grpc.ChainStreamInterceptor(loggingInterceptor.Stream(), metricsInterceptor.Stream(),
+            rateLimitInterceptor.StreamByIP(), authInterceptor.Stream(), rateLimitInterceptor.Stream()),
+    }
+    dialCreds := insecure.NewCredentials()
+
+    var tlsMaterial *tlsMaterial
+    if cfg.TLSEnabled() {
+        tlsMaterial, err = loadTLS(cfg)
+        if err != nil {
+            return nil, fmt.Errorf("unable to load TLS certificates: %w", err)
+        }
+        serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsMaterial.grpcServer)))
+        dialCreds = credentials.NewTLS(tlsMaterial.gateway)
+    }
+
+    grpcServer := grpc.NewServer(serverOpts...)
+
+    grpcHandler := handler.NewHandler(clickHandler, statsHandler, campaignHandler, bannerHandler, apiKeyHandler,
+        impressionHandler, conversionHandler, alertHandler, adminHandler)
     grpcHandler.Register(grpcServer)
 
+    grpcHealth := grpchealth.NewServer()
+    healthpb.RegisterHealthServer(grpcServer, grpcHealth)
+    checker := newHealthChecker(grpcHealth, logger, m, databaseCheck(db, dbReady), replicaCheck, clickUseCase, impressionUseCase)
+
     router := mux.NewRouter()
+    router.Use(tracing.Middleware, logging.Middleware(logger))
+
+    gwmux := runtime.NewServeMux(
+        runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+        runtime.WithOutgoingHeaderMatcher(gatewayOutgoingHeaderMatcher),
+    )
+
+    opts := []grpc.DialOption{
+        grpc.WithTransportCredentials(dialCreds),
+        grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+    }
+
+    if m.ingests() {
+        if err := counter.RegisterCounterServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for CounterService: %w", err)
+        }
+
+        if err := impression.RegisterImpressionServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for ImpressionService: %w", err)
+        }
 
-    gwmux := runtime.NewServeMux()
+        if err := conversion.RegisterConversionServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for ConversionService: %w", err)
+        }
 
-    opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+        if err := admin.RegisterAdminServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for AdminService: %w", err)
+        }
 
-    if err := counter.RegisterCounterServiceHandlerFromEndpoint(context.Background(), 
-        gwmux, cfg.GetGrpcAddress(), opts); err != nil {
-        log.Fatalf("Не удалось зарегистрировать gateway для CounterService: %v", err)
+        guard := httpapi.NewGuard(authUseCase, rateLimitInterceptor, logger)
+        httpapi.NewPixelHandler(guard, impressionUseCase, logger).Register(router)
+        httpapi.NewRedirectHandler(guard, redirectUseCase, logger).Register(router)
+        httpapi.NewPostbackHandler(guard, conversionUseCase, logger).Register(router)
     }
 
-    if err := stats.RegisterStatsServiceHandlerFromEndpoint(context.Background(), 
-        gwmux, cfg.GetGrpcAddress(), opts); err != nil {
-        log.Fatalf("Не удалось зарегистрировать gateway для StatsService: %v", err)
+    if m.queries() {
+        if err := stats.RegisterStatsServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for StatsService: %w", err)
+        }
+
+        if err := campaign.RegisterCampaignServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for CampaignService: %w", err)
+        }
+
+        if err := banner.RegisterBannerServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for BannerService: %w", err)
+        }
+
+        if err := apikey.RegisterApiKeyServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for ApiKeyService: %w", err)
+        }
+
+        if err := alert.RegisterAlertServiceHandlerFromEndpoint(context.Background(), 
+            gwmux, cfg.GetGrpcAddress(), opts); err != nil {
+            return nil, fmt.Errorf("unable to register gateway for AlertService: %w", err)
+        }
     }
 
+    router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+    checker.Register(router)
+
     router.PathPrefix("/").Handler(gwmux)
 
     return &App{
         cfg:    cfg,
+        mode:   m,
+        logger: logger,
         router: router,
         grpc:   grpcServer,
         db:     db,
-    }
+        readDB: readDB,
+        tls:    tlsMaterial,
+        health: checker,
+
+        dbReady:     dbReady,
+        connect:     connect,
+        autoMigrate: autoMigrate,
+
+        alerts:        alertUseCase,
+        alertInterval: alertInterval,
+        capEvents:     capRelay,
+
+        batchers:        batchers,
+        shutdownTimeout: shutdownTimeout,
+        shutdownTracing: shutdownTracing,
+    }, nil
 }
 
+// Run serves until SIGINT or SIGTERM arrives or a component fails, then
+// stops everything within the shutdown timeout. It returns an error unless
+// the service stopped cleanly on a signal.
 func (a *App) Run() error {
-    ctx, cancel := context.WithCancel(context.Background())
-    defer cancel()
-
-    a.router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-        w.WriteHeader(http.StatusOK)
-        fmt.Fprintf(w, "REST API работает")
-    })
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
 
     httpServer := &http.Server{
         Addr:    a.cfg.GetRestAddress(),
         Handler: a.router,
     }
+    if a.tls != nil {
+        httpServer.TLSConfig = a.tls.restServer
+    }
 
+    // Components stop in the reverse of this order: the probes report not
+    // ready first, then the servers finish their requests, the background
+    // jobs end, the batchers write what is queued and the database closes
+    // last.
+    a.logger.Info("Starting", "mode", a.mode)
+    group := supervisor.New(a.logger, a.shutdownTimeout)
+    group.Add(supervisor.Component{
+        Name: "database",
+        Run:  a.connectDatabase,
+        Stop: func(context.Context) error {
+            if a.readDB != a.db {
+                a.readDB.Close()
+            }
+            a.db.Close()
+            return nil
+        },
+    })
+    if a.mode.ingests() {
+        group.Add(supervisor.Component{
+            Name: "batchers",
+            Stop: a.drainBatchers,
+        })
+    }
+    if a.mode.works() {
+        group.Add(supervisor.Component{
+            Name: "alerts",
+            Run: func(ctx context.Context) error {
+                a.alerts.Run(ctx, a.alertInterval)
+                return nil
+            },
+        })
+    }
+    if a.mode.queries() {
+        group.Add(supervisor.Component{
+            Name: "cap events",
+            Run: func(ctx context.Context) error {
+                a.capEvents.Run(ctx)
+                return nil
+            },
+        })
+    }
+    if a.tls != nil {
+        group.Add(supervisor.Component{
+            Name: "certificate watcher",
+            Run: func(ctx context.Context) error {
+                tlsconfig.Watch(ctx, a.logger, certReloadInterval, a.tls.watched...)
+                return nil
+            },
+        })
+    }
+    group.Add(supervisor.Component{
+        Name: "gRPC server",
+        Run:  a.serveGRPC,
+        Stop: a.stopGRPC,
+    })
+    group.Add(supervisor.Component{
+        Name: "REST server",
+        Run: func(context.Context) error {
+            a.logger.Info("Starting REST server", "address", a.cfg.GetRestAddress())
+            if err := a.listenAndServe(httpServer); err != http.ErrServerClosed {
+                return err
+            }
+            return nil
+        },
+        Stop: httpServer.Shutdown,
+    })
+    group.Add(supervisor.Component{
+        Name: "health checker",
+        Run: func(ctx context.Context) error {
+            a.health.Run(ctx, healthCheckInterval)
+            return nil
+        },
+        Stop: func(context.Context) error {
+            a.health.Shutdown()
+            return nil
+        },
+    })
+
+    runErr := group.Run(ctx)
+
+    // Tracing goes last so that the spans of the final flushes are exported.
+    tracingCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+    defer cancel()
+    if err := a.shutdownTracing(tracingCtx); err != nil {
+        a.logger.Error("Unable to stop tracing", "error", err)
+    }
+
+    return runErr
+}
+
+func (a *App) serveGRPC(context.Context) error {
+    lis, err := net.Listen("tcp", a.cfg.GetGrpcAddress())
+    if err != nil {
+        return fmt.Errorf("unable to listen: %w", err)
+    }
+    a.logger.Info("Starting gRPC server", "address", a.cfg.GetGrpcAddress())
+    if err := a.grpc.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+        return err
+    }
+    return nil
+}
+
+// stopGRPC waits for the calls in progress, cutting them off when ctx is
+// done first.
+func (a *App) stopGRPC(ctx context.Context) error {
+    stopped := make(chan struct{})
     go func() {
-        log.Printf("Запуск REST сервера на %s", a.cfg.GetRestAddress())
-        if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
-            log.Printf("Ошибка REST сервера: %v", err)
-        }
+        a.grpc.GracefulStop()
+        close(stopped)
     }()
 
-    go func() {
-        lis, err := net.Listen("tcp", a.cfg.GetGrpcAddress())
-        if err != nil {
-            log.Printf("Не удалось слушать gRPC: %v", err)
-            return
+    select {
+    case <-stopped:
+        return nil
+    case <-ctx.Done():
+        a.grpc.Stop()
+        return ctx.Err()
+    }
+}
+
+// drainBatchers writes the clicks and impressions that are still queued.
+func (a *App) drainBatchers(ctx context.Context) error {
+    var errs []error
+    for _, batcher := range a.batchers {
+        if err := batcher.DrainBatcher(ctx); err != nil {
+            errs = append(errs, err)
         }
-        log.Printf("Запуск gRPC сервера на %s", a.cfg.GetGrpcAddress())
-        if err := a.grpc.Serve(lis); err != nil {
-            log.Printf("Ошибка gRPC сервера: %v", err)
+    }
+    return errors.Join(errs...)
+}
+
+// newHealthChecker checks the database, the read replica unless replica is
+// nil because none is configured and, when the process ingests, the click
+// and impression batchers, and ties the gRPC health status of every service
+// the mode runs to the checks of what it depends on.
+func newHealthChecker(server *grpchealth.Server, logger *slog.Logger, m mode, database, replica health.Func,
+    clicks repository.IngestHealth, impressions repository.IngestHealth) *health.Checker {
+    checker := health.NewChecker(server, logger)
+
+    checker.Readiness("database", database)
+    queryChecks := []string{"database"}
+    if replica != nil {
+        checker.Readiness("database_replica", replica)
+        queryChecks = append(queryChecks, "database_replica")
+    }
+    if m.ingests() {
+        checker.Liveness("clicks_batcher", func(context.Context) error { return clicks.Stalled() })
+        checker.Readiness("clicks_queue", func(context.Context) error { return clicks.Saturated() })
+        checker.Liveness("impressions_batcher", func(context.Context) error { return impressions.Stalled() })
+        checker.Readiness("impressions_queue", func(context.Context) error { return impressions.Saturated() })
+
+        checker.Service(counter.CounterService_ServiceDesc.ServiceName, "database", "clicks_batcher", "clicks_queue")
+        checker.Service(impression.ImpressionService_ServiceDesc.ServiceName, "database", "impressions_batcher", "impressions_queue")
+        checker.Service(conversion.ConversionService_ServiceDesc.ServiceName, "database")
+        checker.Service(admin.AdminService_ServiceDesc.ServiceName)
+    }
+    if m.queries() {
+        for _, service := range []string{
+            stats.StatsService_ServiceDesc.ServiceName,
+            campaign.CampaignService_ServiceDesc.ServiceName,
+            banner.BannerService_ServiceDesc.ServiceName,
+            apikey.ApiKeyService_ServiceDesc.ServiceName,
+            alert.AlertService_ServiceDesc.ServiceName,
+        } {
+            checker.Service(service, queryChecks...)
         }
-    }()
+    }
+    return checker
+}
+
+// configurePool applies the pool and connection settings to dbConfig.
+func configurePool(dbConfig *pgxpool.Config, cfg *config.Config) error {
+    maxConns, err := strconv.ParseInt(cfg.PostgresMaxConns, 10, 32)
+    if err != nil {
+        return fmt.Errorf("invalid POSTGRES_MAX_CONNS: %w", err)
+    }
+    minConns, err := strconv.ParseInt(cfg.PostgresMinConns, 10, 32)
+    if err != nil {
+        return fmt.Errorf("invalid POSTGRES_MIN_CONNS: %w", err)
+    }
+    lifetime, err := time.ParseDuration(cfg.PostgresMaxConnLifetime)
+    if err != nil {
+        return fmt.Errorf("invalid POSTGRES_MAX_CONN_LIFETIME: %w", err)
+    }
+    idleTime, err := time.ParseDuration(cfg.PostgresMaxConnIdleTime)
+    if err != nil {
+        return fmt.Errorf("invalid POSTGRES_MAX_CONN_IDLE_TIME: %w", err)
+    }
+    statementTimeout, err := time.ParseDuration(cfg.PostgresStatementTimeout)
+    if err != nil {
+        return fmt.Errorf("invalid POSTGRES_STATEMENT_TIMEOUT: %w", err)
+    }
 
-    quit := make(chan os.Signal, 1)
-    signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-    <-quit
+    dbConfig.MaxConns = int32(maxConns)
+    dbConfig.MinConns = int32(minConns)
+    dbConfig.MaxConnLifetime = lifetime
+    dbConfig.MaxConnIdleTime = idleTime
+    if statementTimeout > 0 {
+        dbConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(statementTimeout.Milliseconds(), 10)
+    }
+    return nil
+}
 
-    log.Println("Остановка серверов...")
+// newBatchConfig returns the initial settings of the click and impression
+// batchers.
+func newBatchConfig(cfg *config.Config) (usecase.BatchConfig, error) {
+    size, err := strconv.Atoi(cfg.BatchSize)
+    if err != nil || size <= 0 {
+        return usecase.BatchConfig{}, fmt.Errorf("invalid BATCH_SIZE: %q", cfg.BatchSize)
+    }
+    timeout, err := time.ParseDuration(cfg.BatchTimeout)
+    if err != nil || timeout <= 0 {
+        return usecase.BatchConfig{}, fmt.Errorf("invalid BATCH_TIMEOUT: %q", cfg.BatchTimeout)
+    }
+    capacity, err := strconv.Atoi(cfg.BatchQueueCapacity)
+    if err != nil || capacity <= 0 {
+        return usecase.BatchConfig{}, fmt.Errorf("invalid BATCH_QUEUE_CAPACITY: %q", cfg.BatchQueueCapacity)
+    }
+    return usecase.BatchConfig{Size: size, Timeout: timeout, QueueCapacity: capacity}, nil
+}
+
+// newClickTokenSigner returns nil when no click token keys are configured,
+// which leaves click tokens disabled.
+func newClickTokenSigner(cfg *config.Config) (*clicktoken.Signer, error) {
+    keys, _ := clicktoken.ParseKeys(cfg.ClickTokenKeys)
+    if len(keys) == 0 {
+        return nil, nil
+    }
+    ttl, _ := time.ParseDuration(cfg.ClickTokenTTL)
+    return clicktoken.NewSigner(keys, cfg.ClickTokenActiveKey, ttl)
+}
 
-    shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 5*time.Second)
-    defer shutdownCancel()
+// newClickFilter chains the click filters that are enabled. A zero limit or
+// window, or an empty list, disables the corresponding filter.
+func newClickFilter(cfg *config.Config) (repository.ClickFilter, error) {
+    var filters []repository.ClickFilter
 
-    if err := httpServer.Shutdown(shutdownCtx); err != nil {
-        log.Printf("Ошибка при остановке HTTP сервера: %v", err)
+    if patterns := strings.Split(cfg.BotUserAgents, ","); strings.TrimSpace(cfg.BotUserAgents) != "" {
+        filters = append(filters, filter.NewBotFilter(patterns))
     }
 
-    a.grpc.GracefulStop()
-    a.db.Close()
+    if cfg.DatacenterRangesFile != "" {
+        datacenters, err := filter.LoadDatacenterFilter(cfg.DatacenterRangesFile)
+        if err != nil {
+            return nil, err
+        }
+        filters = append(filters, datacenters)
+    }
 
-    return nil
+    duplicateWindow, err := time.ParseDuration(cfg.ClickDuplicateWindow)
+    if err != nil {
+        return nil, fmt.Errorf("invalid CLICK_DUPLICATE_WINDOW: %w", err)
+    }
+    if duplicateWindow > 0 {
+        filters = append(filters, filter.NewDuplicateFilter(duplicateWindow))
+    }
+
+    velocityLimit, err := strconv.Atoi(cfg.ClickVelocityLimit)
+    if err != nil {
+        return nil, fmt.Errorf("invalid CLICK_VELOCITY_LIMIT: %w", err)
+    }
+    velocityWindow, err := time.ParseDuration(cfg.ClickVelocityWindow)
+    if err != nil {
+        return nil, fmt.Errorf("invalid CLICK_VELOCITY_WINDOW: %w", err)
+    }
+    if velocityLimit > 0 && velocityWindow > 0 {
+        filters = append(filters, filter.NewVelocityFilter(velocityLimit, velocityWindow))
+    }
+
+    if len(filters) == 0 {
+        return nil, nil
+    }
+    return filter.Chain(filters...), nil
+}
+
+func (a *App) listenAndServe(server *http.Server) error {
+    if a.tls != nil {
+        return server.ListenAndServeTLS("", "")
+    }
+    return server.ListenAndServe()
+}
+
+// gatewayHeaderMatcher forwards the API key and request id headers to gRPC
+// in addition to the headers the gateway passes on by default,
+// "Authorization" included.
+func gatewayHeaderMatcher(key string) (string, bool) {
+    if strings.EqualFold(key, interceptor.APIKeyHeader) {
+        return interceptor.APIKeyHeader, true
+    }
+    if strings.EqualFold(key, logging.RequestIDHeader) {
+        return strings.ToLower(logging.RequestIDHeader), true
+    }
+    return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayOutgoingHeaderMatcher returns the retry delay of throttled calls as
+// a plain "Retry-After" header and drops the request id, which the router
+// already returns; other metadata keeps the default "Grpc-Metadata-" prefix.
+func gatewayOutgoingHeaderMatcher(key string) (string, bool) {
+    if strings.EqualFold(key, interceptor.RetryAfterHeader) {
+        return "Retry-After", true
+    }
+    if strings.EqualFold(key, logging.RequestIDHeader) {
+        return "", false
+    }
+    return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
 }
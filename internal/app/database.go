@@ -0,0 +1,129 @@
+package app
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strconv"
+    "sync/atomic"
+    "time"
+
+    "clicker/internal/config"
+    "clicker/internal/health"
+    "clicker/internal/tracing"
+
+    "github.com/jackc/pgx/v4"
+    "github.com/jackc/pgx/v4/pgxpool"
+)
+
+// pingTimeout bounds a single connection attempt.
+const pingTimeout = 5 * time.Second
+
+var errDatabaseConnecting = errors.New("connecting to database")
+
+// connectPolicy sets how often and how fast connecting to the database is
+// retried on startup.
+type connectPolicy struct {
+    // attempts is the number of attempts before giving up, 0 for no limit.
+    attempts   int
+    backoff    time.Duration
+    maxBackoff time.Duration
+}
+
+func newConnectPolicy(cfg *config.Config) (connectPolicy, error) {
+    attempts, err := strconv.Atoi(cfg.PostgresConnectAttempts)
+    if err != nil || attempts < 0 {
+        return connectPolicy{}, fmt.Errorf("invalid POSTGRES_CONNECT_ATTEMPTS: %q", cfg.PostgresConnectAttempts)
+    }
+    backoff, err := time.ParseDuration(cfg.PostgresConnectBackoff)
+    if err != nil || backoff <= 0 {
+        return connectPolicy{}, fmt.Errorf("invalid POSTGRES_CONNECT_BACKOFF: %q", cfg.PostgresConnectBackoff)
+    }
+    maxBackoff, err := time.ParseDuration(cfg.PostgresConnectMaxBackoff)
+    if err != nil || maxBackoff < backoff {
+        return connectPolicy{}, fmt.Errorf("invalid POSTGRES_CONNECT_MAX_BACKOFF: %q", cfg.PostgresConnectMaxBackoff)
+    }
+    return connectPolicy{attempts: attempts, backoff: backoff, maxBackoff: maxBackoff}, nil
+}
+
+// newPool creates a pool for dsn with the configured pool settings.
+// Connections are opened on demand, so the servers start while the
+// database is still unavailable; Run waits for it in the background.
+func newPool(dsn string, cfg *config.Config) (*pgxpool.Pool, error) {
+    dbConfig, err := pgxpool.ParseConfig(dsn)
+    if err != nil {
+        return nil, fmt.Errorf("unable to parse PostgreSQL DSN: %w", err)
+    }
+    if err := configurePool(dbConfig, cfg); err != nil {
+        return nil, fmt.Errorf("invalid database pool settings: %w", err)
+    }
+    dbConfig.ConnConfig.Logger = tracing.PgxLogger{}
+    dbConfig.ConnConfig.LogLevel = pgx.LogLevelInfo
+    dbConfig.LazyConnect = true
+
+    db, err := pgxpool.ConnectConfig(context.Background(), dbConfig)
+    if err != nil {
+        return nil, fmt.Errorf("unable to create database pool: %w", err)
+    }
+    return db, nil
+}
+
+// connectDatabase waits for the database to answer, doubling the delay
+// between attempts up to the maximum backoff, and applies pending
+// migrations when configured. The database check reports not ready until
+// it succeeds.
+func (a *App) connectDatabase(ctx context.Context) error {
+    backoff := a.connect.backoff
+    for attempt := 1; ; attempt++ {
+        pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+        err := a.db.Ping(pingCtx)
+        cancel()
+        if err == nil {
+            break
+        }
+        if ctx.Err() != nil {
+            return ctx.Err()
+        }
+        if a.connect.attempts > 0 && attempt >= a.connect.attempts {
+            return fmt.Errorf("unable to connect to database after %d attempts: %w", attempt, err)
+        }
+
+        a.logger.Warn("Database unavailable, retrying", "attempt", attempt, "retry_in", backoff, "error", err)
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        case <-time.After(backoff):
+        }
+        backoff = min(2*backoff, a.connect.maxBackoff)
+    }
+
+    if a.autoMigrate {
+        if err := autoMigrate(ctx, a.db, a.logger); err != nil {
+            return fmt.Errorf("unable to migrate database: %w", err)
+        }
+    }
+
+    a.dbReady.Store(true)
+    a.logger.Info("Connected to database")
+    return nil
+}
+
+// databaseCheck fails until connectDatabase succeeded, and afterwards
+// whenever the database does not answer a ping.
+func databaseCheck(db *pgxpool.Pool, ready *atomic.Bool) health.Func {
+    return func(ctx context.Context) error {
+        if !ready.Load() {
+            return errDatabaseConnecting
+        }
+        return db.Ping(ctx)
+    }
+}
+
+// replicaDatabaseCheck fails whenever the read replica does not answer a
+// ping. Migrations reach it through replication, so it needs no waiting
+// for connectDatabase.
+func replicaDatabaseCheck(db *pgxpool.Pool) health.Func {
+    return func(ctx context.Context) error {
+        return db.Ping(ctx)
+    }
+}
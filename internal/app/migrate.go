@@ -0,0 +1,112 @@
+package app
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "log/slog"
+    "strconv"
+    "text/tabwriter"
+
+    "clicker/internal/config"
+    "clicker/internal/migrate"
+    "clicker/migrations"
+
+    "github.com/jackc/pgx/v4/pgxpool"
+)
+
+// MigrateUsage describes the migrate subcommands.
+const MigrateUsage = `  clicks-counter migrate up [flags]            apply all pending migrations
+  clicks-counter migrate down [N] [flags]      revert the last N migrations (1 by default)
+  clicks-counter migrate goto VERSION [flags]  migrate up or down to VERSION
+  clicks-counter migrate force VERSION [flags] record VERSION as current without running migrations,
+                                               e.g. to adopt a database created without them
+  clicks-counter migrate status [flags]        list migrations and when they were applied`
+
+// Migrate runs a migrate subcommand, such as "up" or "goto", with its
+// argument against the configured database.
+func Migrate(ctx context.Context, cfg *config.Config, logger *slog.Logger, action, arg string, out io.Writer) error {
+    var run func(*migrate.Migrator) error
+    switch action {
+    case "up":
+        run = func(m *migrate.Migrator) error { return m.Up(ctx) }
+    case "down":
+        n := 1
+        if arg != "" {
+            var err error
+            if n, err = strconv.Atoi(arg); err != nil || n < 1 {
+                return fmt.Errorf("invalid number of migrations %q", arg)
+            }
+        }
+        run = func(m *migrate.Migrator) error { return m.Down(ctx, n) }
+    case "goto", "force":
+        version, err := strconv.ParseInt(arg, 10, 64)
+        if err != nil || version < 0 {
+            return fmt.Errorf("invalid migration version %q", arg)
+        }
+        run = func(m *migrate.Migrator) error { return m.Goto(ctx, version) }
+        if action == "force" {
+            run = func(m *migrate.Migrator) error { return m.Force(ctx, version) }
+        }
+    case "status":
+        run = func(m *migrate.Migrator) error {
+            statuses, err := m.Status(ctx)
+            if err != nil {
+                return err
+            }
+            return printMigrations(out, statuses)
+        }
+    default:
+        return fmt.Errorf("unknown migrate command %q", action)
+    }
+
+    migrator, closeDB, err := newMigrator(ctx, cfg, logger)
+    if err != nil {
+        return err
+    }
+    defer closeDB()
+    return run(migrator)
+}
+
+// autoMigrate applies pending migrations on startup.
+func autoMigrate(ctx context.Context, db *pgxpool.Pool, logger *slog.Logger) error {
+    all, err := migrate.Load(migrations.FS)
+    if err != nil {
+        return err
+    }
+    return migrate.New(db, all, logger).Up(ctx)
+}
+
+func newMigrator(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*migrate.Migrator, func(), error) {
+    all, err := migrate.Load(migrations.FS)
+    if err != nil {
+        return nil, nil, err
+    }
+    dbConfig, err := pgxpool.ParseConfig(cfg.GetPostgresDSN())
+    if err != nil {
+        return nil, nil, fmt.Errorf("unable to parse PostgreSQL DSN: %w", err)
+    }
+    dbConfig.MaxConns = 1
+    db, err := pgxpool.ConnectConfig(ctx, dbConfig)
+    if err != nil {
+        return nil, nil, fmt.Errorf("unable to connect to database: %w", err)
+    }
+    return migrate.New(db, all, logger), db.Close, nil
+}
+
+func printMigrations(out io.Writer, statuses []*migrate.Status) error {
+    w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+    for _, status := range statuses {
+        applied := "pending"
+        if status.AppliedAt != nil {
+            applied = status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+        }
+        name := status.Name
+        if status.Missing {
+            name = "(no migration file)"
+        }
+        fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, name, applied)
+    }
+    return w.Flush()
+}
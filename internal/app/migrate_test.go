@@ -0,0 +1,80 @@
+package app
+
+import (
+    "bytes"
+    "context"
+    "io"
+    "log/slog"
+    "strings"
+    "testing"
+    "time"
+
+    "clicker/internal/config"
+    "clicker/internal/migrate"
+)
+
+// unreachable points at a port nothing listens on, so that commands that
+// get past their argument checks fail to connect.
+var unreachable = &config.Config{
+    PostgresHost:    "127.0.0.1",
+    PostgresPort:    "1",
+    PostgresUser:    "clicks_user",
+    PostgresDB:      "clicks_db",
+    PostgresSSLMode: "disable",
+}
+
+func TestMigrateArguments(t *testing.T) {
+    tests := []struct {
+        action, arg string
+        want        string
+    }{
+        {"sideways", "", `unknown migrate command "sideways"`},
+        {"", "", `unknown migrate command ""`},
+        {"down", "0", `invalid number of migrations "0"`},
+        {"down", "-2", `invalid number of migrations "-2"`},
+        {"down", "two", `invalid number of migrations "two"`},
+        {"goto", "", `invalid migration version ""`},
+        {"goto", "-1", `invalid migration version "-1"`},
+        {"force", "v3", `invalid migration version "v3"`},
+        // Valid arguments only fail once the database is reached.
+        {"up", "", "unable to connect to database"},
+        {"down", "", "unable to connect to database"},
+        {"down", "3", "unable to connect to database"},
+        {"goto", "0", "unable to connect to database"},
+        {"force", "12", "unable to connect to database"},
+        {"status", "", "unable to connect to database"},
+    }
+    logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+    for _, tt := range tests {
+        t.Run(tt.action+" "+tt.arg, func(t *testing.T) {
+            ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+            defer cancel()
+            err := Migrate(ctx, unreachable, logger, tt.action, tt.arg, io.Discard)
+            if err == nil || !strings.Contains(err.Error(), tt.want) {
+                t.Errorf("Migrate(%q, %q) = %v, want an error containing %q", tt.action, tt.arg, err, tt.want)
+            }
+        })
+    }
+}
+
+func TestPrintMigrations(t *testing.T) {
+    applied := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+    var out bytes.Buffer
+    err := printMigrations(&out, []*migrate.Status{
+        {Version: 1, Name: "create_banners", AppliedAt: &applied},
+        {Version: 2, Name: "create_clicks"},
+        {Version: 9, AppliedAt: &applied, Missing: true},
+    })
+    if err != nil {
+        t.Fatalf("printMigrations: %v", err)
+    }
+
+    want := `VERSION  NAME                 APPLIED AT
+1        create_banners       2024-05-01 12:00:00
+2        create_clicks        pending
+9        (no migration file)  2024-05-01 12:00:00
+`
+    if out.String() != want {
+        t.Errorf("printMigrations wrote\n%s\nwant\n%s", out.String(), want)
+    }
+}
@@ -0,0 +1,30 @@
+package app
+
+// mode selects the parts of the service a process runs, so that ingestion,
+// queries and background jobs can be scaled separately.
+type mode string
+
+const (
+    // modeAll runs every part in one process.
+    modeAll mode = "all"
+    // modeIngest takes clicks, impressions and conversions, including
+    // redirects, pixels and postbacks, and runs the batchers writing them.
+    modeIngest mode = "ingest"
+    // modeQuery serves stats and the APIs managing campaigns, banners, API
+    // keys and alert rules.
+    modeQuery mode = "query"
+    // modeWorker runs the background jobs, which is alert evaluation.
+    modeWorker mode = "worker"
+)
+
+func (m mode) ingests() bool {
+    return m == modeAll || m == modeIngest
+}
+
+func (m mode) queries() bool {
+    return m == modeAll || m == modeQuery
+}
+
+func (m mode) works() bool {
+    return m == modeAll || m == modeWorker
+}
@@ -0,0 +1,26 @@
+package app
+
+import "testing"
+
+func TestModeParts(t *testing.T) {
+    tests := []struct {
+        mode                    mode
+        ingests, queries, works bool
+    }{
+        {modeAll, true, true, true},
+        {modeIngest, true, false, false},
+        {modeQuery, false, true, false},
+        {modeWorker, false, false, true},
+    }
+    for _, tt := range tests {
+        if got := tt.mode.ingests(); got != tt.ingests {
+            t.Errorf("%s ingests = %v, want %v", tt.mode, got, tt.ingests)
+        }
+        if got := tt.mode.queries(); got != tt.queries {
+            t.Errorf("%s queries = %v, want %v", tt.mode, got, tt.queries)
+        }
+        if got := tt.mode.works(); got != tt.works {
+            t.Errorf("%s works = %v, want %v", tt.mode, got, tt.works)
+        }
+    }
+}
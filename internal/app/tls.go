@@ -0,0 +1,64 @@
+package app
+
+import (
+    "crypto/tls"
+    "fmt"
+
+    "clicker/internal/config"
+    "clicker/internal/tlsconfig"
+)
+
+// tlsMaterial holds the configurations derived from the TLS settings along
+// with the files that are watched for rotation.
+type tlsMaterial struct {
+    grpcServer *tls.Config
+    restServer *tls.Config
+    gateway    *tls.Config
+    watched    []tlsconfig.Reloadable
+}
+
+func loadTLS(cfg *config.Config) (*tlsMaterial, error) {
+    serverPair, err := tlsconfig.LoadKeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+    if err != nil {
+        return nil, err
+    }
+    material := &tlsMaterial{}
+    watched := []tlsconfig.Reloadable{serverPair}
+
+    var clientCAs *tlsconfig.CertPool
+    if cfg.TLSClientCAFile != "" {
+        clientCAs, err = tlsconfig.LoadCertPool(cfg.TLSClientCAFile)
+        if err != nil {
+            return nil, err
+        }
+        watched = append(watched, clientCAs)
+    }
+
+    var roots *tlsconfig.CertPool
+    if cfg.TLSCAFile != "" {
+        roots, err = tlsconfig.LoadCertPool(cfg.TLSCAFile)
+        if err != nil {
+            return nil, err
+        }
+        watched = append(watched, roots)
+    }
+
+    // The gateway is a gRPC client like any other, so with mTLS enabled it
+    // needs a client certificate of its own.
+    var gatewayPair *tlsconfig.KeyPair
+    if cfg.GatewayTLSCertFile != "" {
+        gatewayPair, err = tlsconfig.LoadKeyPair(cfg.GatewayTLSCertFile, cfg.GatewayTLSKeyFile)
+        if err != nil {
+            return nil, err
+        }
+        watched = append(watched, gatewayPair)
+    } else if clientCAs != nil {
+        return nil, fmt.Errorf("GATEWAY_TLS_CERT_FILE is required when TLS_CLIENT_CA_FILE is set")
+    }
+
+    material.grpcServer = tlsconfig.Server(serverPair, clientCAs)
+    material.restServer = tlsconfig.Server(serverPair, nil)
+    material.gateway = tlsconfig.Client(gatewayPair, roots, cfg.TLSServerName)
+    material.watched = watched
+    return material, nil
+}
@@ -0,0 +1,38 @@
+package filter
+
+import (
+    "context"
+    "strings"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+type botFilter struct {
+    patterns []string
+}
+
+// NewBotFilter rejects clicks whose user agent contains any of the patterns,
+// compared case-insensitively, as well as clicks without a user agent.
+func NewBotFilter(patterns []string) repository.ClickFilter {
+    f := &botFilter{}
+    for _, pattern := range patterns {
+        if pattern = strings.ToLower(strings.TrimSpace(pattern)); pattern != "" {
+            f.patterns = append(f.patterns, pattern)
+        }
+    }
+    return f
+}
+
+func (f *botFilter) Check(ctx context.Context, click *entity.Click) entity.RejectReason {
+    ua := strings.ToLower(strings.TrimSpace(click.UserAgent))
+    if ua == "" {
+        return entity.RejectBot
+    }
+    for _, pattern := range f.patterns {
+        if strings.Contains(ua, pattern) {
+            return entity.RejectBot
+        }
+    }
+    return ""
+}
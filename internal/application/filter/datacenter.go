@@ -0,0 +1,58 @@
+package filter
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "net"
+    "os"
+    "strings"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+type datacenterFilter struct {
+    ranges []*net.IPNet
+}
+
+// LoadDatacenterFilter rejects clicks from the IP ranges listed in a file,
+// one CIDR per line. Blank lines and lines starting with "#" are ignored.
+func LoadDatacenterFilter(path string) (repository.ClickFilter, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to open datacenter ranges: %w", err)
+    }
+    defer file.Close()
+
+    f := &datacenterFilter{}
+    scanner := bufio.NewScanner(file)
+    for line := 1; scanner.Scan(); line++ {
+        text := strings.TrimSpace(scanner.Text())
+        if text == "" || strings.HasPrefix(text, "#") {
+            continue
+        }
+        _, ipNet, err := net.ParseCIDR(text)
+        if err != nil {
+            return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+        }
+        f.ranges = append(f.ranges, ipNet)
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, fmt.Errorf("failed to read datacenter ranges: %w", err)
+    }
+    return f, nil
+}
+
+func (f *datacenterFilter) Check(ctx context.Context, click *entity.Click) entity.RejectReason {
+    ip := net.ParseIP(click.IP)
+    if ip == nil {
+        return ""
+    }
+    for _, ipNet := range f.ranges {
+        if ipNet.Contains(ip) {
+            return entity.RejectDatacenterIP
+        }
+    }
+    return ""
+}
@@ -0,0 +1,56 @@
+package filter
+
+import (
+    "context"
+    "crypto/sha256"
+    "strconv"
+    "sync"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+type duplicateFilter struct {
+    window time.Duration
+
+    mu        sync.Mutex
+    lastSeen  map[[sha256.Size]byte]time.Time
+    lastSweep time.Time
+}
+
+// NewDuplicateFilter rejects a click when the same fingerprint (IP, user
+// agent and banner) clicked less than window ago.
+func NewDuplicateFilter(window time.Duration) repository.ClickFilter {
+    return &duplicateFilter{
+        window:   window,
+        lastSeen: make(map[[sha256.Size]byte]time.Time),
+    }
+}
+
+func (f *duplicateFilter) Check(ctx context.Context, click *entity.Click) entity.RejectReason {
+    fingerprint := sha256.Sum256([]byte(click.IP + "\x00" + click.UserAgent + "\x00" +
+        strconv.FormatInt(click.BannerID, 10)))
+    now := click.Timestamp
+
+    f.mu.Lock()
+    defer f.mu.Unlock()
+
+    if now.Sub(f.lastSweep) >= f.window {
+        f.lastSweep = now
+        for key, seen := range f.lastSeen {
+            if now.Sub(seen) >= f.window {
+                delete(f.lastSeen, key)
+            }
+        }
+    }
+
+    // The window restarts on every click, so a steady stream of repeated
+    // clicks keeps being rejected.
+    seen, ok := f.lastSeen[fingerprint]
+    f.lastSeen[fingerprint] = now
+    if ok && now.Sub(seen) < f.window {
+        return entity.RejectDuplicate
+    }
+    return ""
+}
@@ -0,0 +1,27 @@
+// Package filter holds the click filters that separate fraudulent and bot
+// traffic from real clicks.
+package filter
+
+import (
+    "context"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+type chain []repository.ClickFilter
+
+// Chain runs filters in order and stops at the first one that rejects the
+// click.
+func Chain(filters ...repository.ClickFilter) repository.ClickFilter {
+    return chain(filters)
+}
+
+func (c chain) Check(ctx context.Context, click *entity.Click) entity.RejectReason {
+    for _, f := range c {
+        if reason := f.Check(ctx, click); reason != "" {
+            return reason
+        }
+    }
+    return ""
+}
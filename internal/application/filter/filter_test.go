@@ -0,0 +1,165 @@
+package filter
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+const browserUA = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 Chrome/124.0 Safari/537.36"
+
+var start = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func click(ip, ua string, bannerID int64, at time.Duration) *entity.Click {
+    return &entity.Click{IP: ip, UserAgent: ua, BannerID: bannerID, Timestamp: start.Add(at)}
+}
+
+// checkAll runs the clicks through f in order and compares every reason.
+func checkAll(t *testing.T, f repository.ClickFilter, clicks []*entity.Click, want []entity.RejectReason) {
+    t.Helper()
+    for i, c := range clicks {
+        if got := f.Check(context.Background(), c); got != want[i] {
+            t.Errorf("click %d (%s, %q, banner %d, %s): got %q, want %q",
+                i, c.IP, c.UserAgent, c.BannerID, c.Timestamp.Sub(start), got, want[i])
+        }
+    }
+}
+
+func TestBotFilter(t *testing.T) {
+    f := NewBotFilter([]string{"bot", " Crawler ", "", "HeadlessChrome"})
+    tests := []struct {
+        ua   string
+        want entity.RejectReason
+    }{
+        {browserUA, ""},
+        {"Googlebot/2.1 (+http://www.google.com/bot.html)", entity.RejectBot},
+        {"SomeCRAWLER/1.0", entity.RejectBot},
+        {"Mozilla/5.0 HeadlessChrome/120.0", entity.RejectBot},
+        {"", entity.RejectBot},
+        {"   ", entity.RejectBot},
+    }
+    for _, tt := range tests {
+        if got := f.Check(context.Background(), click("203.0.113.5", tt.ua, 1, 0)); got != tt.want {
+            t.Errorf("Check(%q) = %q, want %q", tt.ua, got, tt.want)
+        }
+    }
+}
+
+func TestDatacenterFilter(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "ranges.txt")
+    ranges := "# cloud ranges\n\n198.51.100.0/24\n  2001:db8::/32  \n"
+    if err := os.WriteFile(path, []byte(ranges), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    f, err := LoadDatacenterFilter(path)
+    if err != nil {
+        t.Fatalf("LoadDatacenterFilter: %v", err)
+    }
+
+    tests := []struct {
+        ip   string
+        want entity.RejectReason
+    }{
+        {"203.0.113.5", ""},
+        {"198.51.100.77", entity.RejectDatacenterIP},
+        {"2001:db8::1", entity.RejectDatacenterIP},
+        {"2001:db9::1", ""},
+        {"unknown", ""},
+    }
+    for _, tt := range tests {
+        if got := f.Check(context.Background(), click(tt.ip, browserUA, 1, 0)); got != tt.want {
+            t.Errorf("Check(%s) = %q, want %q", tt.ip, got, tt.want)
+        }
+    }
+}
+
+func TestLoadDatacenterFilterErrors(t *testing.T) {
+    if _, err := LoadDatacenterFilter(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+        t.Error("missing file: expected an error")
+    }
+
+    path := filepath.Join(t.TempDir(), "ranges.txt")
+    if err := os.WriteFile(path, []byte("198.51.100.0/24\nnot-a-cidr\n"), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := LoadDatacenterFilter(path); err == nil {
+        t.Error("invalid range: expected an error")
+    }
+}
+
+func TestDuplicateFilter(t *testing.T) {
+    f := NewDuplicateFilter(10 * time.Second)
+    checkAll(t, f, []*entity.Click{
+        click("203.0.113.5", browserUA, 1, 0),
+        click("203.0.113.5", browserUA, 1, 5*time.Second),
+        // Other banner, IP or user agent is another fingerprint.
+        click("203.0.113.5", browserUA, 2, 6*time.Second),
+        click("203.0.113.6", browserUA, 1, 6*time.Second),
+        click("203.0.113.5", "Other/1.0", 1, 6*time.Second),
+        // The window restarts with every repeat.
+        click("203.0.113.5", browserUA, 1, 14*time.Second),
+        click("203.0.113.5", browserUA, 1, 25*time.Second),
+    }, []entity.RejectReason{"", entity.RejectDuplicate, "", "", "", entity.RejectDuplicate, ""})
+}
+
+func TestVelocityFilter(t *testing.T) {
+    f := NewVelocityFilter(2, time.Minute)
+    checkAll(t, f, []*entity.Click{
+        click("203.0.113.5", browserUA, 1, 0),
+        click("203.0.113.5", browserUA, 1, time.Second),
+        click("203.0.113.5", browserUA, 1, 2*time.Second),
+        // Counted per IP and banner.
+        click("203.0.113.5", browserUA, 2, 3*time.Second),
+        click("203.0.113.6", browserUA, 1, 3*time.Second),
+        // A new window starts the count over.
+        click("203.0.113.5", browserUA, 1, time.Minute),
+    }, []entity.RejectReason{"", "", entity.RejectVelocity, "", "", ""})
+}
+
+type fixedFilter struct {
+    reason entity.RejectReason
+    calls  int
+}
+
+func (f *fixedFilter) Check(context.Context, *entity.Click) entity.RejectReason {
+    f.calls++
+    return f.reason
+}
+
+func TestChain(t *testing.T) {
+    tests := []struct {
+        name      string
+        reasons   []entity.RejectReason
+        want      entity.RejectReason
+        wantCalls []int
+    }{
+        {"empty chain passes", nil, "", nil},
+        {"all pass", []entity.RejectReason{"", ""}, "", []int{1, 1}},
+        {"first rejection wins", []entity.RejectReason{"", entity.RejectBot, entity.RejectVelocity}, entity.RejectBot, []int{1, 1, 0}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var filters []repository.ClickFilter
+            var fixed []*fixedFilter
+            for _, reason := range tt.reasons {
+                f := &fixedFilter{reason: reason}
+                fixed = append(fixed, f)
+                filters = append(filters, f)
+            }
+
+            if got := Chain(filters...).Check(context.Background(), click("203.0.113.5", browserUA, 1, 0)); got != tt.want {
+                t.Errorf("Check = %q, want %q", got, tt.want)
+            }
+            for i, f := range fixed {
+                if f.calls != tt.wantCalls[i] {
+                    t.Errorf("filter %d called %d times, want %d", i, f.calls, tt.wantCalls[i])
+                }
+            }
+        })
+    }
+}
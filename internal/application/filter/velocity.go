@@ -0,0 +1,56 @@
+package filter
+
+import (
+    "context"
+    "strconv"
+    "sync"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+// window counts events per key in fixed time windows.
+type window struct {
+    mu     sync.Mutex
+    length time.Duration
+    start  time.Time
+    counts map[string]int
+}
+
+func newWindow(length time.Duration) *window {
+    return &window{length: length, counts: make(map[string]int)}
+}
+
+// add counts an event for key and returns the number of events for key in
+// the current window, including this one.
+func (w *window) add(key string, now time.Time) int {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+
+    if now.Sub(w.start) >= w.length {
+        w.start = now
+        w.counts = make(map[string]int)
+    }
+    w.counts[key]++
+    return w.counts[key]
+}
+
+type velocityFilter struct {
+    limit  int
+    window *window
+}
+
+// NewVelocityFilter rejects clicks once an IP has clicked the same banner
+// more than limit times within the window.
+func NewVelocityFilter(limit int, length time.Duration) repository.ClickFilter {
+    return &velocityFilter{limit: limit, window: newWindow(length)}
+}
+
+func (f *velocityFilter) Check(ctx context.Context, click *entity.Click) entity.RejectReason {
+    key := click.IP + "|" + strconv.FormatInt(click.BannerID, 10)
+    if f.window.add(key, click.Timestamp) > f.limit {
+        return entity.RejectVelocity
+    }
+    return ""
+}
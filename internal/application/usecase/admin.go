@@ -0,0 +1,57 @@
+package usecase
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+const (
+    minBatchTimeout = 10 * time.Millisecond
+    maxBatchTimeout = time.Minute
+)
+
+type adminUseCase struct {
+    batchers []repository.BatchControl
+}
+
+// NewAdminUseCase creates the use case that tunes the given batchers at
+// runtime. Batchers are addressed by the name in their settings.
+func NewAdminUseCase(batchers ...repository.BatchControl) repository.AdminUseCase {
+    return &adminUseCase{batchers: batchers}
+}
+
+func (uc *adminUseCase) ListBatchers(ctx context.Context) ([]*entity.BatchSettings, error) {
+    settings := make([]*entity.BatchSettings, len(uc.batchers))
+    for i, b := range uc.batchers {
+        settings[i] = b.BatchSettings()
+    }
+    return settings, nil
+}
+
+func (uc *adminUseCase) UpdateBatcher(ctx context.Context, name string, size int, timeout time.Duration) (*entity.BatchSettings, error) {
+    for _, b := range uc.batchers {
+        current := b.BatchSettings()
+        if current.Name != name {
+            continue
+        }
+        if err := validateBatchSettings(current, size, timeout); err != nil {
+            return nil, err
+        }
+        return b.TuneBatcher(size, timeout), nil
+    }
+    return nil, entity.ErrBatcherNotFound
+}
+
+func validateBatchSettings(current *entity.BatchSettings, size int, timeout time.Duration) error {
+    if size < 0 || size > current.QueueCapacity {
+        return fmt.Errorf("%w: size must be between 1 and the queue capacity %d", entity.ErrInvalidBatchSettings, current.QueueCapacity)
+    }
+    if timeout != 0 && (timeout < minBatchTimeout || timeout > maxBatchTimeout) {
+        return fmt.Errorf("%w: timeout must be between %s and %s", entity.ErrInvalidBatchSettings, minBatchTimeout, maxBatchTimeout)
+    }
+    return nil
+}
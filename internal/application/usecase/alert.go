@@ -0,0 +1,190 @@
+package usecase
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+    "strings"
+    "sync"
+    "time"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+const minAlertWindow = time.Minute
+
+type alertUseCase struct {
+    repo     repository.AlertRepository
+    stats    repository.StatsRepository
+    notifier repository.AlertNotifier
+    logger   *slog.Logger
+    // notifying tracks the notifications that are being sent.
+    notifying sync.WaitGroup
+}
+
+func NewAlertUseCase(repo repository.AlertRepository, stats repository.StatsRepository,
+    notifier repository.AlertNotifier, logger *slog.Logger) repository.AlertUseCase {
+    return &alertUseCase{
+        repo:     repo,
+        stats:    stats,
+        notifier: notifier,
+        logger:   logger,
+    }
+}
+
+func (uc *alertUseCase) CreateRule(ctx context.Context, rule *entity.AlertRule) (*entity.AlertRule, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    rule.TenantID = tenantID
+    rule.Name = strings.TrimSpace(rule.Name)
+    if err := validateAlertRule(rule); err != nil {
+        return nil, err
+    }
+    if err := uc.notifier.CheckURL(ctx, rule.WebhookURL); err != nil {
+        return nil, fmt.Errorf("%w: %v", entity.ErrInvalidAlertRule, err)
+    }
+
+    if err := uc.repo.Create(ctx, rule); err != nil {
+        return nil, err
+    }
+    return rule, nil
+}
+
+func (uc *alertUseCase) ListRules(ctx context.Context) ([]*entity.AlertRule, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return uc.repo.List(ctx, tenantID)
+}
+
+func (uc *alertUseCase) DeleteRule(ctx context.Context, id int64) error {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return err
+    }
+    return uc.repo.Delete(ctx, tenantID, id)
+}
+
+func (uc *alertUseCase) Run(ctx context.Context, interval time.Duration) {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+    defer uc.notifying.Wait()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case now := <-ticker.C:
+            if err := uc.evaluate(ctx, now); err != nil {
+                uc.logger.ErrorContext(ctx, "Failed to evaluate alert rules", "error", err)
+            }
+        }
+    }
+}
+
+func (uc *alertUseCase) evaluate(ctx context.Context, now time.Time) error {
+    rules, err := uc.repo.ListAll(ctx)
+    if err != nil {
+        return err
+    }
+    for _, rule := range rules {
+        if err := uc.evaluateRule(ctx, rule, now); err != nil {
+            uc.logger.ErrorContext(ctx, "Failed to evaluate alert rule", "rule_id", rule.ID, "error", err)
+        }
+    }
+    return nil
+}
+
+func (uc *alertUseCase) evaluateRule(ctx context.Context, rule *entity.AlertRule, now time.Time) error {
+    from := now.Add(-rule.Window)
+    current, err := uc.stats.CountClicks(ctx, rule.TenantID, rule.BannerID, from, now)
+    if err != nil {
+        return err
+    }
+    var previous int64
+    if rule.Kind == entity.AlertChange {
+        previous, err = uc.stats.CountClicks(ctx, rule.TenantID, rule.BannerID, from.Add(-rule.Window), from)
+        if err != nil {
+            return err
+        }
+    }
+
+    firing, value, ok := rule.Evaluate(current, previous)
+    if !ok {
+        return nil
+    }
+
+    previousState := rule.State
+    rule.State = entity.AlertOK
+    if firing {
+        rule.State = entity.AlertFiring
+    }
+    rule.Value = &value
+    rule.EvaluatedAt = &now
+    changed := rule.State != previousState
+    if changed {
+        rule.StateChangedAt = &now
+    }
+
+    saved, err := uc.repo.SaveState(ctx, rule, previousState)
+    if err != nil || !saved || !changed {
+        return err
+    }
+
+    notification := &entity.AlertNotification{
+        RuleID:    rule.ID,
+        RuleName:  rule.Name,
+        TenantID:  rule.TenantID,
+        BannerID:  rule.BannerID,
+        Kind:      rule.Kind,
+        Operator:  rule.Operator,
+        Threshold: rule.Threshold,
+        Window:    int64(rule.Window.Seconds()),
+        State:     rule.State,
+        Value:     value,
+        At:        now,
+    }
+    uc.notifying.Add(1)
+    go func() {
+        defer uc.notifying.Done()
+        if err := uc.notifier.Notify(ctx, rule.WebhookURL, notification); err != nil {
+            uc.logger.ErrorContext(ctx, "Failed to notify alert rule", "rule_id", rule.ID, "error", err)
+        }
+    }()
+    return nil
+}
+
+func validateAlertRule(rule *entity.AlertRule) error {
+    if rule.Name == "" {
+        return fmt.Errorf("%w: name must not be empty", entity.ErrInvalidAlertRule)
+    }
+
+    switch rule.Kind {
+    case entity.AlertThreshold, entity.AlertChange:
+        if rule.Operator == "" {
+            rule.Operator = entity.AlertAbove
+        }
+        if rule.Operator != entity.AlertAbove && rule.Operator != entity.AlertBelow {
+            return fmt.Errorf("%w: unknown operator %q", entity.ErrInvalidAlertRule, rule.Operator)
+        }
+        if rule.Threshold < 0 {
+            return fmt.Errorf("%w: threshold must not be negative", entity.ErrInvalidAlertRule)
+        }
+    case entity.AlertNoData:
+        rule.Operator = ""
+        rule.Threshold = 0
+    default:
+        return fmt.Errorf("%w: unknown kind %q", entity.ErrInvalidAlertRule, rule.Kind)
+    }
+
+    if rule.Window < minAlertWindow {
+        return fmt.Errorf("%w: window must be at least %s", entity.ErrInvalidAlertRule, minAlertWindow)
+    }
+    return nil
+}
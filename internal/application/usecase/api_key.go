@@ -0,0 +1,120 @@
+package usecase
+
+import (
+    "context"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "fmt"
+    "strings"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+const (
+    apiKeyPrefix            = "ck_"
+    publishableAPIKeyPrefix = "pk_"
+    apiKeyBytes             = 32
+    apiKeyDisplayChars      = 8
+)
+
+type apiKeyUseCase struct {
+    repo repository.APIKeyRepository
+}
+
+func NewAPIKeyUseCase(repo repository.APIKeyRepository) repository.APIKeyUseCase {
+    return &apiKeyUseCase{
+        repo: repo,
+    }
+}
+
+func (uc *apiKeyUseCase) Issue(ctx context.Context, name string, rawScopes []string, publishable bool) (*entity.APIKey, string, error) {
+    identity, ok := auth.FromContext(ctx)
+    if !ok {
+        return nil, "", auth.ErrUnauthenticated
+    }
+
+    name = strings.TrimSpace(name)
+    if name == "" {
+        return nil, "", fmt.Errorf("api key name must not be empty")
+    }
+
+    scopes, err := auth.ParseScopes(rawScopes)
+    if err != nil {
+        return nil, "", err
+    }
+    // A key can never grant more than the credential that issues it.
+    for _, scope := range scopes {
+        if !identity.HasScope(scope) {
+            return nil, "", fmt.Errorf("%w: cannot grant scope %q", auth.ErrPermissionDenied, scope)
+        }
+        if publishable && !publishableScope(scope) {
+            return nil, "", fmt.Errorf("%w: %q", auth.ErrPublishableScope, scope)
+        }
+    }
+
+    prefix := apiKeyPrefix
+    if publishable {
+        prefix = publishableAPIKeyPrefix
+    }
+
+    secret := make([]byte, apiKeyBytes)
+    if _, err := rand.Read(secret); err != nil {
+        return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+    }
+    plaintext := prefix + base64.RawURLEncoding.EncodeToString(secret)
+    hash := sha256.Sum256([]byte(plaintext))
+
+    key := &entity.APIKey{
+        TenantID:    identity.TenantID,
+        Name:        name,
+        Prefix:      plaintext[:len(prefix)+apiKeyDisplayChars],
+        Hash:        hash[:],
+        Scopes:      uniqueScopes(scopes),
+        Publishable: publishable,
+    }
+    if err := uc.repo.Create(ctx, key); err != nil {
+        return nil, "", err
+    }
+    return key, plaintext, nil
+}
+
+func (uc *apiKeyUseCase) List(ctx context.Context) ([]*entity.APIKey, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return uc.repo.List(ctx, tenantID)
+}
+
+func (uc *apiKeyUseCase) Revoke(ctx context.Context, id int64) error {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return err
+    }
+    return uc.repo.Revoke(ctx, tenantID, id)
+}
+
+func publishableScope(scope auth.Scope) bool {
+    for _, allowed := range auth.PublishableScopes {
+        if scope == allowed {
+            return true
+        }
+    }
+    return false
+}
+
+func uniqueScopes(scopes []auth.Scope) []string {
+    seen := make(map[auth.Scope]struct{}, len(scopes))
+    result := make([]string, 0, len(scopes))
+    for _, scope := range scopes {
+        if _, ok := seen[scope]; ok {
+            continue
+        }
+        seen[scope] = struct{}{}
+        result = append(result, string(scope))
+    }
+    return result
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+)
+
+// memoryAPIKeys keeps issued keys by their hash.
+type memoryAPIKeys map[string]*entity.APIKey
+
+func (m memoryAPIKeys) Create(ctx context.Context, key *entity.APIKey) error {
+    key.ID = int64(len(m) + 1)
+    m[string(key.Hash)] = key
+    return nil
+}
+
+func (m memoryAPIKeys) GetActiveByHash(ctx context.Context, hash []byte) (*entity.APIKey, error) {
+    if key, ok := m[string(hash)]; ok {
+        return key, nil
+    }
+    return nil, auth.ErrUnauthenticated
+}
+
+func (m memoryAPIKeys) List(ctx context.Context, tenantID int64) ([]*entity.APIKey, error) {
+    return nil, nil
+}
+
+func (m memoryAPIKeys) Revoke(ctx context.Context, tenantID, id int64) error {
+    return nil
+}
+
+func TestIssuePublishableKey(t *testing.T) {
+    keys := memoryAPIKeys{}
+    issuer := NewAPIKeyUseCase(keys)
+    ctx := auth.WithIdentity(context.Background(), &auth.Identity{TenantID: 7, KeyID: 1, Scopes: auth.AllScopes})
+
+    tests := []struct {
+        name        string
+        scopes      []string
+        publishable bool
+        prefix      string
+        wantErr     error
+    }{
+        {"secret", []string{"stats:read", "clicks:write"}, false, "ck_", nil},
+        {"publishable", []string{"clicks:write", "impressions:write"}, true, "pk_", nil},
+        {"publishable with secret scope", []string{"clicks:write", "stats:read"}, true, "", auth.ErrPublishableScope},
+        {"publishable postback key", []string{"conversions:write"}, true, "", auth.ErrPublishableScope},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            key, plaintext, err := issuer.Issue(ctx, tt.name, tt.scopes, tt.publishable)
+            if !errors.Is(err, tt.wantErr) {
+                t.Fatalf("Issue error = %v, want %v", err, tt.wantErr)
+            }
+            if err != nil {
+                return
+            }
+            if !strings.HasPrefix(plaintext, tt.prefix) || !strings.HasPrefix(key.Prefix, tt.prefix) {
+                t.Errorf("key %q (prefix %q), want prefix %q", plaintext, key.Prefix, tt.prefix)
+            }
+
+            identity, err := NewAuthUseCase(keys, nil, "").Authenticate(context.Background(), plaintext)
+            if err != nil {
+                t.Fatalf("Authenticate: %v", err)
+            }
+            if identity.Publishable != tt.publishable || identity.TenantID != 7 {
+                t.Errorf("Authenticate = %+v, want publishable %v in tenant 7", identity, tt.publishable)
+            }
+        })
+    }
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+    "context"
+    "crypto/sha256"
+    "crypto/subtle"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/repository"
+)
+
+type authUseCase struct {
+    repo repository.APIKeyRepository
+    jwt  *auth.JWTVerifier
+    // operator is the SHA-256 of the operator token, nil without one.
+    operator []byte
+}
+
+// NewAuthUseCase accepts API keys and, when jwt is not nil, user JWTs. An
+// empty operatorToken leaves the operator scope to no one.
+func NewAuthUseCase(repo repository.APIKeyRepository, jwt *auth.JWTVerifier, operatorToken string) repository.AuthUseCase {
+    uc := &authUseCase{
+        repo: repo,
+        jwt:  jwt,
+    }
+    if operatorToken != "" {
+        hash := sha256.Sum256([]byte(operatorToken))
+        uc.operator = hash[:]
+    }
+    return uc
+}
+
+// Authenticate resolves a credential to the caller's identity. JWTs carry
+// their tenant and role in claims; API keys are resolved to the tenant and
+// scopes they were issued with, and only the SHA-256 of a key is ever stored
+// or compared.
+func (uc *authUseCase) Authenticate(ctx context.Context, key string) (*auth.Identity, error) {
+    if key == "" {
+        return nil, auth.ErrUnauthenticated
+    }
+    if uc.jwt != nil && auth.LooksLikeJWT(key) {
+        return uc.jwt.Verify(key)
+    }
+
+    hash := sha256.Sum256([]byte(key))
+    if uc.operator != nil && subtle.ConstantTimeCompare(hash[:], uc.operator) == 1 {
+        return &auth.Identity{Subject: "operator", Operator: true}, nil
+    }
+    apiKey, err := uc.repo.GetActiveByHash(ctx, hash[:])
+    if err != nil {
+        return nil, err
+    }
+
+    scopes := make([]auth.Scope, len(apiKey.Scopes))
+    for i, scope := range apiKey.Scopes {
+        scopes[i] = auth.Scope(scope)
+    }
+    return &auth.Identity{
+        TenantID:    apiKey.TenantID,
+        KeyID:       apiKey.ID,
+        Scopes:      scopes,
+        Publishable: apiKey.Publishable,
+    }, nil
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "time"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+const minutesPerDay = 24 * 60
+
+type bannerUseCase struct {
+    repo      repository.BannerRepository
+    capEvents repository.CapEventSubscriber
+    redirects entity.DomainAllowlist
+}
+
+func NewBannerUseCase(repo repository.BannerRepository, capEvents repository.CapEventSubscriber,
+    redirects entity.DomainAllowlist) repository.BannerUseCase {
+    return &bannerUseCase{
+        repo:      repo,
+        capEvents: capEvents,
+        redirects: redirects,
+    }
+}
+
+func (uc *bannerUseCase) Get(ctx context.Context, id int64) (*entity.Banner, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return uc.repo.Get(ctx, tenantID, id)
+}
+
+func (uc *bannerUseCase) SetFlight(ctx context.Context, banner *entity.Banner) (*entity.Banner, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    banner.TenantID = tenantID
+
+    if banner.Timezone == "" {
+        banner.Timezone = "UTC"
+    }
+    if err := validateFlight(banner); err != nil {
+        return nil, err
+    }
+
+    if err := uc.repo.UpdateFlight(ctx, banner); err != nil {
+        return nil, err
+    }
+    return uc.repo.Get(ctx, tenantID, banner.ID)
+}
+
+func (uc *bannerUseCase) SetCaps(ctx context.Context, banner *entity.Banner) (*entity.Banner, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    banner.TenantID = tenantID
+
+    if banner.DailyClickCap != nil && *banner.DailyClickCap <= 0 {
+        return nil, fmt.Errorf("%w: daily cap must be positive", entity.ErrInvalidCaps)
+    }
+    if banner.LifetimeClickCap != nil && *banner.LifetimeClickCap <= 0 {
+        return nil, fmt.Errorf("%w: lifetime cap must be positive", entity.ErrInvalidCaps)
+    }
+
+    if err := uc.repo.UpdateCaps(ctx, banner); err != nil {
+        return nil, err
+    }
+    return uc.repo.Get(ctx, tenantID, banner.ID)
+}
+
+func (uc *bannerUseCase) SetTargetURL(ctx context.Context, id int64, targetURL string) (*entity.Banner, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    targetURL = strings.TrimSpace(targetURL)
+    if targetURL != "" {
+        if err := uc.redirects.ValidateTargetURL(targetURL); err != nil {
+            return nil, err
+        }
+    }
+
+    banner := &entity.Banner{ID: id, TenantID: tenantID, TargetURL: targetURL}
+    if err := uc.repo.UpdateTargetURL(ctx, banner); err != nil {
+        return nil, err
+    }
+    return uc.repo.Get(ctx, tenantID, id)
+}
+
+// WatchCapEvents streams the cap events of the caller's tenant until ctx is
+// done, at which point the returned channel is closed.
+func (uc *bannerUseCase) WatchCapEvents(ctx context.Context) (<-chan *entity.CapEvent, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    events, unsubscribe := uc.capEvents.Subscribe()
+    out := make(chan *entity.CapEvent)
+    go func() {
+        defer close(out)
+        defer unsubscribe()
+
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case event, ok := <-events:
+                if !ok {
+                    return
+                }
+                if event.TenantID != tenantID {
+                    continue
+                }
+                select {
+                case out <- event:
+                case <-ctx.Done():
+                    return
+                }
+            }
+        }
+    }()
+    return out, nil
+}
+
+func validateFlight(banner *entity.Banner) error {
+    if banner.StartsAt != nil && banner.EndsAt != nil && !banner.StartsAt.Before(*banner.EndsAt) {
+        return fmt.Errorf("%w: starts_at must be before ends_at", entity.ErrInvalidFlight)
+    }
+    if _, err := time.LoadLocation(banner.Timezone); err != nil {
+        return fmt.Errorf("%w: unknown timezone %q", entity.ErrInvalidFlight, banner.Timezone)
+    }
+    for _, daypart := range banner.Dayparts {
+        if daypart.StartMinute < 0 || daypart.EndMinute > minutesPerDay || daypart.StartMinute >= daypart.EndMinute {
+            return fmt.Errorf("%w: daypart must satisfy 0 <= start_minute < end_minute <= %d",
+                entity.ErrInvalidFlight, minutesPerDay)
+        }
+        for _, w := range daypart.Weekdays {
+            if w < time.Sunday || w > time.Saturday {
+                return fmt.Errorf("%w: weekday %d out of range", entity.ErrInvalidFlight, w)
+            }
+        }
+    }
+    return nil
+}
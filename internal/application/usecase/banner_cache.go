@@ -0,0 +1,74 @@
+package usecase
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+const bannerCacheTTL = 30 * time.Second
+
+type bannerKey struct {
+    tenantID int64
+    id       int64
+}
+
+type cachedBanner struct {
+    banner    *entity.Banner
+    location  *time.Location
+    expiresAt time.Time
+}
+
+// bannerCache keeps recently used banners in memory so that registering a
+// click does not cost an extra round trip to the database. Entries expire
+// after bannerCacheTTL, which bounds how long a schedule change takes to
+// reach every instance.
+type bannerCache struct {
+    repo    repository.BannerRepository
+    ttl     time.Duration
+    mu      sync.RWMutex
+    entries map[bannerKey]*cachedBanner
+}
+
+func newBannerCache(repo repository.BannerRepository, ttl time.Duration) *bannerCache {
+    return &bannerCache{
+        repo:    repo,
+        ttl:     ttl,
+        entries: make(map[bannerKey]*cachedBanner),
+    }
+}
+
+func (c *bannerCache) Get(ctx context.Context, tenantID, id int64) (*entity.Banner, *time.Location, error) {
+    now := time.Now()
+    key := bannerKey{tenantID: tenantID, id: id}
+
+    c.mu.RLock()
+    entry, ok := c.entries[key]
+    c.mu.RUnlock()
+    if ok && now.Before(entry.expiresAt) {
+        return entry.banner, entry.location, nil
+    }
+
+    banner, err := c.repo.Get(ctx, tenantID, id)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    location, err := time.LoadLocation(banner.Timezone)
+    if err != nil {
+        location = time.UTC
+    }
+
+    c.mu.Lock()
+    c.entries[key] = &cachedBanner{
+        banner:    banner,
+        location:  location,
+        expiresAt: now.Add(c.ttl),
+    }
+    c.mu.Unlock()
+
+    return banner, location, nil
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/metrics"
+    "clicker/internal/tracing"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// BatchConfig sets up a batcher.
+type BatchConfig struct {
+    // Size is the number of items after which a batch is written.
+    Size int
+    // Timeout is how long collected items wait at most for a full batch.
+    Timeout time.Duration
+    // QueueCapacity is the number of items that can wait to be batched
+    // before Add blocks.
+    QueueCapacity int
+}
+
+const (
+    // maxBatchLinks bounds the requests a flush span links to.
+    maxBatchLinks = 128
+    // batchStallTimeout is how long a batcher may go without taking an item
+    // or a tick, for example because a flush hangs, before it counts as
+    // stuck.
+    batchStallTimeout = 30 * time.Second
+    // batchSaturation is the share of the queue above which a batcher
+    // reports itself saturated.
+    batchSaturation = 0.9
+)
+
+type queued[T any] struct {
+    item T
+    span trace.SpanContext
+}
+
+// batcher collects items written one at a time and hands them to flush in
+// batches of up to size items, or whatever has been collected after timeout.
+// Items of a batch that flush fails to write are lost. Each flush runs in a
+// span of its own, linked to the spans of the requests that added the items.
+// Size and timeout can be changed while the batcher runs, and Drain writes
+// what is left when it has to stop.
+type batcher[T any] struct {
+    name    string
+    items   chan queued[T]
+    size    atomic.Int64
+    timeout atomic.Int64
+    // retune wakes run up after the timeout changed.
+    retune chan struct{}
+    flush  func(context.Context, []T) error
+    // heartbeat is the time, in Unix nanoseconds, at which run last went
+    // round its loop.
+    heartbeat atomic.Int64
+    // stop asks run to write what is queued and return, which it reports
+    // by closing done.
+    stop     chan struct{}
+    stopOnce sync.Once
+    done     chan struct{}
+}
+
+// newBatcher starts a batcher; name labels its metrics.
+func newBatcher[T any](name string, cfg BatchConfig, flush func(context.Context, []T) error) *batcher[T] {
+    b := &batcher[T]{
+        name:   name,
+        items:  make(chan queued[T], cfg.QueueCapacity),
+        retune: make(chan struct{}, 1),
+        flush:  flush,
+        stop:   make(chan struct{}),
+        done:   make(chan struct{}),
+    }
+    b.size.Store(int64(cfg.Size))
+    b.timeout.Store(int64(cfg.Timeout))
+    b.heartbeat.Store(time.Now().UnixNano())
+    go b.run()
+    return b
+}
+
+// Add queues an item, blocking while the queue is full. Items added after
+// Drain are never written.
+func (b *batcher[T]) Add(ctx context.Context, item T) {
+    b.items <- queued[T]{item: item, span: trace.SpanContextFromContext(ctx)}
+    metrics.BatchQueueDepth.WithLabelValues(b.name).Set(float64(len(b.items)))
+}
+
+// Settings reports the current settings of the batcher.
+func (b *batcher[T]) Settings() *entity.BatchSettings {
+    return &entity.BatchSettings{
+        Name:          b.name,
+        Size:          int(b.size.Load()),
+        Timeout:       time.Duration(b.timeout.Load()),
+        QueueCapacity: cap(b.items),
+        QueueDepth:    len(b.items),
+    }
+}
+
+// Tune changes the batch size and timeout; zero values keep the current
+// setting. The new size applies from the next item on, the new timeout from
+// the next tick.
+func (b *batcher[T]) Tune(size int, timeout time.Duration) *entity.BatchSettings {
+    if size > 0 {
+        b.size.Store(int64(size))
+    }
+    if timeout > 0 && b.timeout.Swap(int64(timeout)) != int64(timeout) {
+        select {
+        case b.retune <- struct{}{}:
+        default:
+        }
+    }
+    return b.Settings()
+}
+
+// Drain writes the queued items and stops the batcher. It returns early
+// when ctx is done, leaving the last batches to be written in the
+// background.
+func (b *batcher[T]) Drain(ctx context.Context) error {
+    b.stopOnce.Do(func() { close(b.stop) })
+    select {
+    case <-b.done:
+        return nil
+    case <-ctx.Done():
+        return fmt.Errorf("%s batcher still holds %d queued items: %w", b.name, len(b.items), ctx.Err())
+    }
+}
+
+func (b *batcher[T]) run() {
+    defer close(b.done)
+
+    batch := make([]T, 0, b.size.Load())
+    var links []trace.Link
+    collect := func(q queued[T]) {
+        batch = append(batch, q.item)
+        if q.span.IsValid() && len(links) < maxBatchLinks {
+            links = append(links, trace.Link{SpanContext: q.span})
+        }
+        if size := int(b.size.Load()); len(batch) >= size {
+            b.write(batch, links, "size")
+            batch, links = make([]T, 0, size), nil
+        }
+    }
+
+    ticker := time.NewTicker(time.Duration(b.timeout.Load()))
+    defer ticker.Stop()
+
+    for {
+        b.heartbeat.Store(time.Now().UnixNano())
+        select {
+        case q := <-b.items:
+            collect(q)
+        case <-ticker.C:
+            if len(batch) > 0 {
+                b.write(batch, links, "timeout")
+                batch, links = make([]T, 0, b.size.Load()), nil
+            }
+        case <-b.retune:
+            ticker.Reset(time.Duration(b.timeout.Load()))
+        case <-b.stop:
+            // run is the only reader, so whatever is queued now can be
+            // taken without blocking.
+            for len(b.items) > 0 {
+                collect(<-b.items)
+            }
+            if len(batch) > 0 {
+                b.write(batch, links, "drain")
+            }
+            return
+        }
+    }
+}
+
+// Stalled returns an error when the batcher has stopped going round its
+// loop, so that queued items are no longer written.
+func (b *batcher[T]) Stalled() error {
+    since := time.Since(time.Unix(0, b.heartbeat.Load()))
+    if since > batchStallTimeout+time.Duration(b.timeout.Load()) {
+        return fmt.Errorf("%s batcher stuck for %s", b.name, since.Round(time.Second))
+    }
+    return nil
+}
+
+// Saturated returns an error when the queue is nearly full, at which point
+// Add starts blocking requests.
+func (b *batcher[T]) Saturated() error {
+    if depth := len(b.items); float64(depth) >= batchSaturation*float64(cap(b.items)) {
+        return fmt.Errorf("%s queue holds %d of %d items", b.name, depth, cap(b.items))
+    }
+    return nil
+}
+
+func (b *batcher[T]) write(batch []T, links []trace.Link, reason string) {
+    metrics.BatchQueueDepth.WithLabelValues(b.name).Set(float64(len(b.items)))
+    metrics.BatchFlushes.WithLabelValues(b.name, reason).Inc()
+    metrics.BatchSize.WithLabelValues(b.name).Observe(float64(len(batch)))
+
+    ctx, span := tracing.Start(context.Background(), "batch.flush "+b.name,
+        trace.WithLinks(links...),
+        trace.WithAttributes(
+            attribute.String("batch.reason", reason),
+            attribute.Int("batch.size", len(batch)),
+        ),
+    )
+    start := time.Now()
+    err := b.flush(ctx, batch)
+    tracing.End(span, err)
+    metrics.BatchFlushDuration.WithLabelValues(b.name).Observe(time.Since(start).Seconds())
+
+    if err != nil {
+        metrics.BatchFlushFailures.WithLabelValues(b.name).Inc()
+        metrics.BatchDropped.WithLabelValues(b.name).Add(float64(len(batch)))
+        return
+    }
+    metrics.BatchRowsWritten.WithLabelValues(b.name).Add(float64(len(batch)))
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+    "context"
+    "errors"
+    "slices"
+    "sync"
+    "testing"
+    "time"
+)
+
+// flushes records the batches a batcher writes.
+type flushes struct {
+    mu      sync.Mutex
+    batches [][]int
+    written chan struct{}
+}
+
+func newFlushes() *flushes {
+    return &flushes{written: make(chan struct{}, 100)}
+}
+
+func (f *flushes) flush(ctx context.Context, batch []int) error {
+    f.mu.Lock()
+    f.batches = append(f.batches, append([]int(nil), batch...))
+    f.mu.Unlock()
+    f.written <- struct{}{}
+    return nil
+}
+
+func (f *flushes) sizes() []int {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    var sizes []int
+    for _, batch := range f.batches {
+        sizes = append(sizes, len(batch))
+    }
+    return sizes
+}
+
+func (f *flushes) wait(t *testing.T) {
+    t.Helper()
+    select {
+    case <-f.written:
+    case <-time.After(time.Second):
+        t.Fatal("no batch was written")
+    }
+}
+
+func TestBatcherDrainWritesQueuedItems(t *testing.T) {
+    f := newFlushes()
+    b := newBatcher("test", BatchConfig{Size: 3, Timeout: time.Hour, QueueCapacity: 10}, f.flush)
+    for i := 0; i < 7; i++ {
+        b.Add(context.Background(), i)
+    }
+
+    if err := b.Drain(context.Background()); err != nil {
+        t.Fatalf("Drain: %v", err)
+    }
+    if got := f.sizes(); !slices.Equal(got, []int{3, 3, 1}) {
+        t.Errorf("batch sizes = %v, want [3 3 1]", got)
+    }
+    if err := b.Drain(context.Background()); err != nil {
+        t.Errorf("second Drain: %v", err)
+    }
+}
+
+func TestBatcherDrainGivesUp(t *testing.T) {
+    release := make(chan struct{})
+    b := newBatcher("test", BatchConfig{Size: 1, Timeout: time.Hour, QueueCapacity: 10}, func(ctx context.Context, batch []int) error {
+        <-release
+        return nil
+    })
+    defer close(release)
+    b.Add(context.Background(), 1)
+    b.Add(context.Background(), 2)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+    defer cancel()
+    if err := b.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+        t.Fatalf("Drain = %v, want context.DeadlineExceeded", err)
+    }
+}
+
+func TestBatcherTuneSize(t *testing.T) {
+    f := newFlushes()
+    b := newBatcher("test", BatchConfig{Size: 100, Timeout: time.Hour, QueueCapacity: 10}, f.flush)
+    defer b.Drain(context.Background())
+
+    settings := b.Tune(2, 0)
+    if settings.Size != 2 || settings.Timeout != time.Hour || settings.QueueCapacity != 10 {
+        t.Fatalf("Tune = %+v, want size 2 and the timeout kept", settings)
+    }
+    for i := 0; i < 4; i++ {
+        b.Add(context.Background(), i)
+    }
+    f.wait(t)
+    f.wait(t)
+    if got := f.sizes(); !slices.Equal(got, []int{2, 2}) {
+        t.Errorf("batch sizes = %v, want [2 2]", got)
+    }
+}
+
+func TestBatcherTuneTimeout(t *testing.T) {
+    f := newFlushes()
+    b := newBatcher("test", BatchConfig{Size: 100, Timeout: time.Hour, QueueCapacity: 10}, f.flush)
+    defer b.Drain(context.Background())
+
+    settings := b.Tune(0, 10*time.Millisecond)
+    if settings.Size != 100 || settings.Timeout != 10*time.Millisecond {
+        t.Fatalf("Tune = %+v, want the size kept and a 10ms timeout", settings)
+    }
+    b.Add(context.Background(), 1)
+    f.wait(t)
+    if got := f.sizes(); !slices.Equal(got, []int{1}) {
+        t.Errorf("batch sizes = %v, want [1]", got)
+    }
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "strings"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+type campaignUseCase struct {
+    repo     repository.CampaignRepository
+    sketches repository.SketchRepository
+}
+
+func NewCampaignUseCase(repo repository.CampaignRepository, sketches repository.SketchRepository) repository.CampaignUseCase {
+    return &campaignUseCase{
+        repo:     repo,
+        sketches: sketches,
+    }
+}
+
+func (uc *campaignUseCase) Create(ctx context.Context, name string, bannerIDs []int64) (*entity.Campaign, error) {
+    name = strings.TrimSpace(name)
+    if name == "" {
+        return nil, fmt.Errorf("campaign name must not be empty")
+    }
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    campaign := &entity.Campaign{
+        TenantID:  tenantID,
+        Name:      name,
+        BannerIDs: uniqueIDs(bannerIDs),
+    }
+    if err := uc.repo.Create(ctx, campaign); err != nil {
+        return nil, err
+    }
+    return campaign, nil
+}
+
+func (uc *campaignUseCase) Get(ctx context.Context, id int64) (*entity.Campaign, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return uc.repo.Get(ctx, tenantID, id)
+}
+
+func (uc *campaignUseCase) List(ctx context.Context) ([]*entity.Campaign, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return uc.repo.List(ctx, tenantID)
+}
+
+func (uc *campaignUseCase) Update(ctx context.Context, id int64, name string) (*entity.Campaign, error) {
+    name = strings.TrimSpace(name)
+    if name == "" {
+        return nil, fmt.Errorf("campaign name must not be empty")
+    }
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := uc.repo.Update(ctx, &entity.Campaign{ID: id, TenantID: tenantID, Name: name}); err != nil {
+        return nil, err
+    }
+    return uc.repo.Get(ctx, tenantID, id)
+}
+
+func (uc *campaignUseCase) Delete(ctx context.Context, id int64) error {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return err
+    }
+    return uc.repo.Delete(ctx, tenantID, id)
+}
+
+func (uc *campaignUseCase) AddBanners(ctx context.Context, campaignID int64, bannerIDs []int64) (*entity.Campaign, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := uc.repo.AddBanners(ctx, tenantID, campaignID, uniqueIDs(bannerIDs)); err != nil {
+        return nil, err
+    }
+    return uc.repo.Get(ctx, tenantID, campaignID)
+}
+
+func (uc *campaignUseCase) RemoveBanners(ctx context.Context, campaignID int64, bannerIDs []int64) (*entity.Campaign, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := uc.repo.RemoveBanners(ctx, tenantID, campaignID, uniqueIDs(bannerIDs)); err != nil {
+        return nil, err
+    }
+    return uc.repo.Get(ctx, tenantID, campaignID)
+}
+
+func (uc *campaignUseCase) Counter(ctx context.Context, campaignID int64) (*entity.CampaignStats, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    campaign, err := uc.repo.Get(ctx, tenantID, campaignID)
+    if err != nil {
+        return nil, err
+    }
+
+    banners, err := uc.repo.GetTotalClicks(ctx, tenantID, campaignID)
+    if err != nil {
+        return nil, err
+    }
+
+    // A clicker of several banners is counted once for the campaign.
+    unique, err := uc.sketches.GetLifetime(ctx, tenantID, campaign.BannerIDs)
+    if err != nil {
+        return nil, err
+    }
+
+    result := &entity.CampaignStats{
+        CampaignID:   campaignID,
+        UniqueClicks: unique.Estimate(),
+        Banners:      banners,
+    }
+    for _, banner := range banners {
+        result.TotalClicks += banner.TotalClicks
+    }
+    return result, nil
+}
+
+func (uc *campaignUseCase) Stats(ctx context.Context, campaignID int64, filter repository.StatsFilter) (*entity.CampaignStats, error) {
+    if filter.From.After(filter.To) {
+        return nil, entity.ErrInvalidTimeRange
+    }
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    campaign, err := uc.repo.Get(ctx, tenantID, campaignID)
+    if err != nil {
+        return nil, err
+    }
+
+    clicks, err := uc.repo.GetStats(ctx, tenantID, campaignID, filter)
+    if err != nil {
+        return nil, err
+    }
+
+    unique, err := uc.sketches.GetRange(ctx, tenantID, campaign.BannerIDs, filter.From, filter.To)
+    if err != nil {
+        return nil, err
+    }
+
+    result := aggregateCampaignStats(campaign, clicks)
+    result.UniqueClicks = unique.Estimate()
+    return result, nil
+}
+
+// aggregateCampaignStats folds clicks of all campaign banners into a single
+// time series while keeping each banner's own series for the breakdown.
+// Clicks are expected to be ordered by timestamp.
+func aggregateCampaignStats(campaign *entity.Campaign, clicks []*entity.Click) *entity.CampaignStats {
+    result := &entity.CampaignStats{
+        CampaignID: campaign.ID,
+        Stats:      make([]*entity.Click, 0),
+        Banners:    make([]*entity.BannerClicks, 0, len(campaign.BannerIDs)),
+    }
+
+    perBanner := make(map[int64]*entity.BannerClicks, len(campaign.BannerIDs))
+    for _, id := range campaign.BannerIDs {
+        banner := &entity.BannerClicks{BannerID: id, Stats: make([]*entity.Click, 0)}
+        perBanner[id] = banner
+        result.Banners = append(result.Banners, banner)
+    }
+
+    var last *entity.Click
+    for _, click := range clicks {
+        if last == nil || !last.Timestamp.Equal(click.Timestamp) || last.OutOfFlight != click.OutOfFlight ||
+            last.RejectReason != click.RejectReason {
+            last = &entity.Click{Timestamp: click.Timestamp, OutOfFlight: click.OutOfFlight, RejectReason: click.RejectReason}
+            result.Stats = append(result.Stats, last)
+        }
+        last.Count += click.Count
+        result.TotalClicks += int64(click.Count)
+
+        banner, ok := perBanner[click.BannerID]
+        if !ok {
+            continue
+        }
+        banner.TotalClicks += int64(click.Count)
+        banner.Stats = append(banner.Stats, click)
+    }
+
+    return result
+}
+
+func uniqueIDs(ids []int64) []int64 {
+    seen := make(map[int64]struct{}, len(ids))
+    result := make([]int64, 0, len(ids))
+    for _, id := range ids {
+        if _, ok := seen[id]; ok {
+            continue
+        }
+        seen[id] = struct{}{}
+        result = append(result, id)
+    }
+    sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+    return result
+}
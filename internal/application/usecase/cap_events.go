@@ -0,0 +1,119 @@
+package usecase
+
+import (
+    "context"
+    "log/slog"
+    "sync"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+const (
+    capEventBuffer = 64
+
+    // capEventNotifyTimeout bounds the wait for the database when a cap
+    // event is published.
+    capEventNotifyTimeout = 5 * time.Second
+    // capEventRelistenDelay is the pause before listening again after the
+    // connection of the listener failed.
+    capEventRelistenDelay = 5 * time.Second
+)
+
+// CapEventHub fans cap events out to every current subscriber. Slow
+// subscribers lose events rather than blocking click registration.
+type CapEventHub struct {
+    mu          sync.Mutex
+    subscribers map[chan *entity.CapEvent]struct{}
+}
+
+var (
+    _ repository.CapEventPublisher  = (*CapEventHub)(nil)
+    _ repository.CapEventSubscriber = (*CapEventHub)(nil)
+)
+
+func NewCapEventHub() *CapEventHub {
+    return &CapEventHub{
+        subscribers: make(map[chan *entity.CapEvent]struct{}),
+    }
+}
+
+func (h *CapEventHub) Publish(event *entity.CapEvent) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+
+    for ch := range h.subscribers {
+        select {
+        case ch <- event:
+        default:
+        }
+    }
+}
+
+func (h *CapEventHub) Subscribe() (<-chan *entity.CapEvent, func()) {
+    ch := make(chan *entity.CapEvent, capEventBuffer)
+
+    h.mu.Lock()
+    h.subscribers[ch] = struct{}{}
+    h.mu.Unlock()
+
+    var once sync.Once
+    return ch, func() {
+        once.Do(func() {
+            h.mu.Lock()
+            delete(h.subscribers, ch)
+            h.mu.Unlock()
+            close(ch)
+        })
+    }
+}
+
+// CapEventRelay carries cap events through the database, so that every
+// process streaming them, on whichever node, hears about the caps reached
+// by the clicks of every ingest node. Ingest nodes publish to it and query
+// nodes run it to feed their hub.
+type CapEventRelay struct {
+    bus           repository.CapEventBus
+    hub           repository.CapEventPublisher
+    logger        *slog.Logger
+    relistenDelay time.Duration
+}
+
+var _ repository.CapEventPublisher = (*CapEventRelay)(nil)
+
+func NewCapEventRelay(bus repository.CapEventBus, hub repository.CapEventPublisher, logger *slog.Logger) *CapEventRelay {
+    return &CapEventRelay{bus: bus, hub: hub, logger: logger, relistenDelay: capEventRelistenDelay}
+}
+
+// Publish sends event to the listeners in the background, so that click
+// registration never waits for the database. Like the hub, the relay
+// loses events rather than holding clicks up.
+func (r *CapEventRelay) Publish(event *entity.CapEvent) {
+    go func() {
+        ctx, cancel := context.WithTimeout(context.Background(), capEventNotifyTimeout)
+        defer cancel()
+
+        if err := r.bus.Notify(ctx, event); err != nil {
+            r.logger.ErrorContext(ctx, "Failed to publish cap event", "banner_id", event.BannerID, "kind", event.Kind, "error", err)
+        }
+    }()
+}
+
+// Run hands the events on the bus to the hub until ctx is done, listening
+// again after the connection fails.
+func (r *CapEventRelay) Run(ctx context.Context) {
+    for {
+        err := r.bus.Listen(ctx, r.hub.Publish)
+        if ctx.Err() != nil {
+            return
+        }
+        r.logger.ErrorContext(ctx, "Failed to listen for cap events", "error", err)
+
+        select {
+        case <-ctx.Done():
+            return
+        case <-time.After(r.relistenDelay):
+        }
+    }
+}
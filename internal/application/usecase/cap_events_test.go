@@ -0,0 +1,83 @@
+package usecase
+
+import (
+    "context"
+    "errors"
+    "io"
+    "log/slog"
+    "testing"
+    "time"
+
+    "clicker/internal/domain/entity"
+)
+
+// memoryBus delivers notified events to its listener, failing the first
+// listens it is told to.
+type memoryBus struct {
+    events   chan *entity.CapEvent
+    failures chan struct{}
+}
+
+func newMemoryBus(failures int) *memoryBus {
+    b := &memoryBus{events: make(chan *entity.CapEvent, 10), failures: make(chan struct{}, failures)}
+    for i := 0; i < failures; i++ {
+        b.failures <- struct{}{}
+    }
+    return b
+}
+
+func (b *memoryBus) Notify(ctx context.Context, event *entity.CapEvent) error {
+    b.events <- event
+    return nil
+}
+
+func (b *memoryBus) Listen(ctx context.Context, fn func(*entity.CapEvent)) error {
+    select {
+    case <-b.failures:
+        return errors.New("connection lost")
+    default:
+    }
+    for {
+        select {
+        case <-ctx.Done():
+            return nil
+        case event := <-b.events:
+            fn(event)
+        }
+    }
+}
+
+func TestCapEventRelay(t *testing.T) {
+    hub := NewCapEventHub()
+    events, unsubscribe := hub.Subscribe()
+    defer unsubscribe()
+
+    relay := NewCapEventRelay(newMemoryBus(2), hub, slog.New(slog.NewTextHandler(io.Discard, nil)))
+    relay.relistenDelay = time.Millisecond
+
+    ctx, cancel := context.WithCancel(context.Background())
+    stopped := make(chan struct{})
+    go func() {
+        relay.Run(ctx)
+        close(stopped)
+    }()
+
+    // The event is published by the click path of one process and streamed
+    // from the hub of another, after the listener recovered.
+    relay.Publish(&entity.CapEvent{TenantID: 1, BannerID: 42, Kind: entity.CapDaily, Cap: 100})
+    select {
+    case event := <-events:
+        if event.BannerID != 42 || event.Kind != entity.CapDaily || event.Cap != 100 {
+            t.Errorf("event = %+v, want the daily cap of banner 42", event)
+        }
+    case <-time.After(time.Second):
+        t.Fatal("the event did not reach the hub")
+    }
+
+    cancel()
+    select {
+    case <-stopped:
+    case <-time.After(time.Second):
+        t.Fatal("Run did not return after ctx was done")
+    }
+}
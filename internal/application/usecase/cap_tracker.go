@@ -0,0 +1,162 @@
+package usecase
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+const capReconcileInterval = 30 * time.Second
+
+type capCounter struct {
+    mu           sync.Mutex
+    day          time.Time
+    daily        int64
+    lifetime     int64
+    reconciledAt time.Time
+    dailyHit     bool
+    lifetimeHit  bool
+}
+
+// capTracker enforces per-banner click caps from in-memory counters. The
+// counters are periodically reconciled with the database, taking the larger
+// of the two values, so clicks accepted by other instances are eventually
+// accounted for while our own unflushed clicks are never forgotten.
+type capTracker struct {
+    repo      repository.ClickRepository
+    publisher repository.CapEventPublisher
+    interval  time.Duration
+    mu        sync.Mutex
+    counters  map[int64]*capCounter
+}
+
+func newCapTracker(repo repository.ClickRepository, publisher repository.CapEventPublisher, interval time.Duration) *capTracker {
+    return &capTracker{
+        repo:      repo,
+        publisher: publisher,
+        interval:  interval,
+        counters:  make(map[int64]*capCounter),
+    }
+}
+
+// Reserve counts a click against the banner's caps. It returns
+// entity.ClickAccepted if the click fits within every cap, otherwise the
+// status of the cap that is exhausted; in that case nothing is counted.
+func (t *capTracker) Reserve(ctx context.Context, banner *entity.Banner, loc *time.Location, now time.Time) (entity.ClickStatus, error) {
+    if !banner.HasCaps() {
+        return entity.ClickAccepted, nil
+    }
+
+    c := t.counter(banner.ID)
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    day := startOfDay(now, loc)
+    if !c.day.Equal(day) {
+        c.day = day
+        c.daily = 0
+        c.dailyHit = false
+        c.reconciledAt = time.Time{}
+    }
+
+    if now.Sub(c.reconciledAt) >= t.interval {
+        if err := t.reconcile(ctx, banner, c); err != nil {
+            return entity.ClickAccepted, err
+        }
+        c.reconciledAt = now
+    }
+
+    if banner.LifetimeClickCap != nil && c.lifetime >= *banner.LifetimeClickCap {
+        t.notify(c, banner, entity.CapLifetime, now)
+        return entity.ClickLifetimeCapReached, nil
+    }
+    if banner.DailyClickCap != nil && c.daily >= *banner.DailyClickCap {
+        t.notify(c, banner, entity.CapDaily, now)
+        return entity.ClickDailyCapReached, nil
+    }
+
+    // Below every cap again, e.g. after a cap was raised: re-arm the events.
+    c.lifetimeHit = false
+    c.dailyHit = false
+    c.lifetime++
+    c.daily++
+
+    if banner.LifetimeClickCap != nil && c.lifetime >= *banner.LifetimeClickCap {
+        t.notify(c, banner, entity.CapLifetime, now)
+    } else if banner.DailyClickCap != nil && c.daily >= *banner.DailyClickCap {
+        t.notify(c, banner, entity.CapDaily, now)
+    }
+
+    return entity.ClickAccepted, nil
+}
+
+func (t *capTracker) counter(bannerID int64) *capCounter {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+
+    c, ok := t.counters[bannerID]
+    if !ok {
+        c = &capCounter{}
+        t.counters[bannerID] = c
+    }
+    return c
+}
+
+func (t *capTracker) reconcile(ctx context.Context, banner *entity.Banner, c *capCounter) error {
+    lifetime, err := t.repo.GetTotalClicks(ctx, banner.TenantID, banner.ID)
+    if err != nil {
+        return err
+    }
+    daily, err := t.repo.GetClicksSince(ctx, banner.TenantID, banner.ID, c.day)
+    if err != nil {
+        return err
+    }
+
+    if lifetime > c.lifetime {
+        c.lifetime = lifetime
+    }
+    if daily > c.daily {
+        c.daily = daily
+    }
+    return nil
+}
+
+// notify publishes a cap event the first time a cap is found exhausted for
+// the current counter period.
+func (t *capTracker) notify(c *capCounter, banner *entity.Banner, kind entity.CapKind, now time.Time) {
+    event := &entity.CapEvent{
+        TenantID:  banner.TenantID,
+        BannerID:  banner.ID,
+        Kind:      kind,
+        ReachedAt: now,
+    }
+
+    switch kind {
+    case entity.CapLifetime:
+        if c.lifetimeHit {
+            return
+        }
+        c.lifetimeHit = true
+        event.Cap = *banner.LifetimeClickCap
+    case entity.CapDaily:
+        if c.dailyHit {
+            return
+        }
+        c.dailyHit = true
+        event.Cap = *banner.DailyClickCap
+        resetsAt := c.day.AddDate(0, 0, 1)
+        event.ResetsAt = &resetsAt
+    }
+
+    if t.publisher != nil {
+        t.publisher.Publish(event)
+    }
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+    local := t.In(loc)
+    return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
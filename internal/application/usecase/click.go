@@ -2,60 +2,306 @@ package usecase
 
 import (
     "context"
+    "crypto/rand"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "log/slog"
     "time"
 
+    "clicker/internal/auth"
+    "clicker/internal/clicktoken"
     "clicker/internal/domain/entity"
     "clicker/internal/domain/repository"
+    "clicker/internal/hll"
+    "clicker/internal/metrics"
+    "clicker/internal/tracing"
+)
+
+// FlightPolicy decides what happens to a click that arrives outside of its
+// banner's flight.
+type FlightPolicy string
+
+const (
+    // FlightPolicyFlag stores the click marked as out-of-flight so that it
+    // is excluded from default stats but can still be inspected.
+    FlightPolicyFlag FlightPolicy = "flag"
+    // FlightPolicyReject drops the click and reports entity.ErrOutOfFlight.
+    FlightPolicyReject FlightPolicy = "reject"
 )
 
 type clickUseCase struct {
     repo      repository.ClickRepository
-    clickChan chan *entity.Click
-    batchSize int
-    batchTimeout time.Duration
+    sketches  repository.SketchRepository
+    banners   *bannerCache
+    caps      *capTracker
+    policy    FlightPolicy
+    tokens    *clicktoken.Signer
+    replays   *clicktoken.ReplayCache
+    filter    repository.ClickFilter
+    clicks    *batcher[*entity.Click]
+    logger    *slog.Logger
 }
 
-func NewClickUseCase(repo repository.ClickRepository) repository.ClickUseCase {
+// NewClickUseCase creates the click use case, which writes clicks in
+// batches set up by batch. With a nil tokens signer clicks are accepted
+// without click tokens, and with a nil filter every click is considered
+// valid.
+func NewClickUseCase(repo repository.ClickRepository, sketches repository.SketchRepository, bannerRepo repository.BannerRepository,
+    capEvents repository.CapEventPublisher, policy FlightPolicy, tokens *clicktoken.Signer,
+    filter repository.ClickFilter, batch BatchConfig, logger *slog.Logger) repository.ClickUseCase {
     uc := &clickUseCase{
-        repo:         repo,
-        clickChan:    make(chan *entity.Click, 1000),
-        batchSize:    100,
-        batchTimeout: time.Second,
+        repo:     repo,
+        sketches: sketches,
+        banners:  newBannerCache(bannerRepo, bannerCacheTTL),
+        caps:     newCapTracker(repo, capEvents, capReconcileInterval),
+        policy:   policy,
+        tokens:   tokens,
+        replays:  clicktoken.NewReplayCache(),
+        filter:   filter,
+        logger:   logger,
     }
-    go uc.processBatch()
+    uc.clicks = newBatcher("clicks", batch, uc.flush)
     return uc
 }
 
-func (uc *clickUseCase) Counter(ctx context.Context, bannerID int64) (int64, error) {
-    uc.clickChan <- &entity.Click{
-        BannerID:  bannerID,
-        Timestamp: time.Now(),
+func (uc *clickUseCase) Counter(ctx context.Context, req *entity.ClickRequest) (*entity.CounterResult, error) {
+    ctx, span := tracing.Start(ctx, "clickUseCase.Counter")
+    defer span.End()
+
+    bannerID := req.BannerID
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    banner, location, err := uc.banners.Get(ctx, tenantID, bannerID)
+    if err != nil {
+        return nil, err
+    }
+
+    clickID, err := newClickID()
+    if err != nil {
+        return nil, err
+    }
+
+    click := &entity.Click{
+        ClickID:     clickID,
+        BannerID:    bannerID,
+        Timestamp:   time.Now(),
+        IP:          req.IP,
+        UserAgent:   req.UserAgent,
+        Fingerprint: req.Fingerprint(),
+    }
+    if reason := uc.checkToken(bannerID, req.Token, click.Timestamp); reason != "" {
+        click.RejectReason = reason
+        uc.clicks.Add(ctx, click)
+        metrics.CountClick(bannerID, "rejected")
+        if reason == entity.RejectExpiredToken {
+            return nil, entity.ErrClickTokenExpired
+        }
+        return nil, entity.ErrInvalidClickToken
+    }
+
+    // Invalid clicks are answered exactly like accepted ones so that
+    // filtered clients cannot tell which of their clicks were counted; the
+    // reason is only kept with the stored click.
+    if uc.filter != nil {
+        if reason := uc.filter.Check(ctx, click); reason != "" {
+            click.RejectReason = reason
+            uc.clicks.Add(ctx, click)
+            metrics.CountClick(bannerID, "invalid")
+            return uc.result(ctx, tenantID, click, entity.ClickAccepted)
+        }
+    }
+
+    if !banner.InFlight(click.Timestamp, location) {
+        if uc.policy == FlightPolicyReject {
+            metrics.CountClick(bannerID, "out_of_flight")
+            return nil, entity.ErrOutOfFlight
+        }
+        click.OutOfFlight = true
+    }
+
+    status := entity.ClickAccepted
+    if !click.OutOfFlight {
+        status, err = uc.caps.Reserve(ctx, banner, location, click.Timestamp)
+        if err != nil {
+            return nil, err
+        }
+    }
+    if status == entity.ClickAccepted {
+        uc.clicks.Add(ctx, click)
+    }
+    metrics.CountClick(bannerID, clickOutcome(click, status))
+
+    return uc.result(ctx, tenantID, click, status)
+}
+
+func (uc *clickUseCase) result(ctx context.Context, tenantID int64, click *entity.Click, status entity.ClickStatus) (*entity.CounterResult, error) {
+    total, err := uc.repo.GetTotalClicks(ctx, tenantID, click.BannerID)
+    if err != nil {
+        return nil, err
+    }
+    unique, err := uc.sketches.GetLifetime(ctx, tenantID, []int64{click.BannerID})
+    if err != nil {
+        return nil, err
+    }
+    result := &entity.CounterResult{
+        TotalClicks:  total,
+        UniqueClicks: unique.Estimate(),
+        OutOfFlight:  click.OutOfFlight,
+        Status:       status,
+    }
+    // Capped clicks are not stored, so their id would lead nowhere. Invalid
+    // clicks are stored with their id, which conversions refuse.
+    if status == entity.ClickAccepted {
+        result.ClickID = click.ClickID
+    }
+    return result, nil
+}
+
+func (uc *clickUseCase) IssueToken(ctx context.Context, bannerID int64, placement string) (*entity.ClickToken, error) {
+    ctx, span := tracing.Start(ctx, "clickUseCase.IssueToken")
+    defer span.End()
+
+    if uc.tokens == nil {
+        return nil, entity.ErrClickTokensDisabled
+    }
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    if _, _, err := uc.banners.Get(ctx, tenantID, bannerID); err != nil {
+        return nil, err
+    }
+
+    value, token, err := uc.tokens.Issue(bannerID, placement, time.Now())
+    if err != nil {
+        return nil, err
+    }
+    return &entity.ClickToken{
+        Value:     value,
+        BannerID:  token.BannerID,
+        Placement: token.Placement,
+        ExpiresAt: token.ExpiresAt,
+    }, nil
+}
+
+func (uc *clickUseCase) Stalled() error {
+    return uc.clicks.Stalled()
+}
+
+func (uc *clickUseCase) Saturated() error {
+    return uc.clicks.Saturated()
+}
+
+func (uc *clickUseCase) BatchSettings() *entity.BatchSettings {
+    return uc.clicks.Settings()
+}
+
+func (uc *clickUseCase) TuneBatcher(size int, timeout time.Duration) *entity.BatchSettings {
+    return uc.clicks.Tune(size, timeout)
+}
+
+func (uc *clickUseCase) DrainBatcher(ctx context.Context) error {
+    return uc.clicks.Drain(ctx)
+}
+
+// clickOutcome labels a counted or capped click for metrics.
+func clickOutcome(click *entity.Click, status entity.ClickStatus) string {
+    switch {
+    case status == entity.ClickDailyCapReached:
+        return "daily_cap"
+    case status == entity.ClickLifetimeCapReached:
+        return "lifetime_cap"
+    case click.OutOfFlight:
+        return "out_of_flight"
     }
-    return uc.repo.GetTotalClicks(ctx, bannerID)
+    return "accepted"
 }
 
-func (uc *clickUseCase) Stats(ctx context.Context, bannerID int64, from, to time.Time) ([]*entity.Click, error) {
-    return uc.repo.GetStats(ctx, bannerID, from, to)
+// newClickID returns a random identifier for a click.
+func newClickID() (string, error) {
+    raw := make([]byte, 16)
+    if _, err := rand.Read(raw); err != nil {
+        return "", fmt.Errorf("failed to generate click id: %w", err)
+    }
+    return hex.EncodeToString(raw), nil
 }
 
-func (uc *clickUseCase) processBatch() {
-    batch := make([]*entity.Click, 0, uc.batchSize)
-    ticker := time.NewTicker(uc.batchTimeout)
-    defer ticker.Stop()
+// checkToken verifies the click token of a click on the banner and returns
+// why the click has to be rejected, or "" if it may be counted.
+func (uc *clickUseCase) checkToken(bannerID int64, value string, now time.Time) entity.RejectReason {
+    if uc.tokens == nil {
+        return ""
+    }
+    if value == "" {
+        return entity.RejectMissingToken
+    }
+
+    token, err := uc.tokens.Verify(value, now)
+    switch {
+    case errors.Is(err, clicktoken.ErrExpired):
+        return entity.RejectExpiredToken
+    case err != nil, token.BannerID != bannerID:
+        return entity.RejectInvalidToken
+    case !uc.replays.Use(token, now):
+        return entity.RejectReplayedToken
+    }
+    return ""
+}
+
+func (uc *clickUseCase) Stats(ctx context.Context, bannerID int64, filter repository.StatsFilter) ([]*entity.Click, error) {
+    ctx, span := tracing.Start(ctx, "clickUseCase.Stats")
+    defer span.End()
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return uc.repo.GetStats(ctx, tenantID, bannerID, filter)
+}
 
-    for {
-        select {
-        case click := <-uc.clickChan:
-            batch = append(batch, click)
-            if len(batch) >= uc.batchSize {
-                uc.repo.SaveBatch(context.Background(), batch)
-                batch = make([]*entity.Click, 0, uc.batchSize)
-            }
-        case <-ticker.C:
-            if len(batch) > 0 {
-                uc.repo.SaveBatch(context.Background(), batch)
-                batch = make([]*entity.Click, 0, uc.batchSize)
-            }
+func (uc *clickUseCase) flush(ctx context.Context, batch []*entity.Click) error {
+    if err := uc.repo.SaveBatch(ctx, batch); err != nil {
+        uc.logger.ErrorContext(ctx, "Failed to save clicks", "clicks", len(batch), "error", err)
+        return err
+    }
+    // The clicks are stored at this point, so a failed merge only costs
+    // unique click estimates.
+    if sketches := sketchBatch(batch); len(sketches) > 0 {
+        if err := uc.sketches.Merge(ctx, sketches); err != nil {
+            uc.logger.ErrorContext(ctx, "Failed to merge click sketches", "error", err)
+        }
+    }
+    return nil
+}
+
+// sketchBatch builds one sketch per banner and bucket from the fingerprints
+// of the counted clicks in a batch.
+func sketchBatch(batch []*entity.Click) []*entity.ClickSketch {
+    type key struct {
+        bannerID int64
+        bucket   time.Time
+    }
+
+    byKey := make(map[key]*entity.ClickSketch)
+    var sketches []*entity.ClickSketch
+    for _, click := range batch {
+        if click.RejectReason != "" || click.OutOfFlight || click.Fingerprint == "" {
+            continue
+        }
+        k := key{bannerID: click.BannerID, bucket: click.Timestamp.Truncate(entity.SketchBucket)}
+        sketch, ok := byKey[k]
+        if !ok {
+            sketch = &entity.ClickSketch{BannerID: k.bannerID, Bucket: k.bucket, Sketch: hll.New()}
+            byKey[k] = sketch
+            sketches = append(sketches, sketch)
         }
+        sketch.Sketch.AddString(click.Fingerprint)
     }
+    return sketches
 }
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+    "bytes"
+    "testing"
+    "time"
+
+    "clicker/internal/clicktoken"
+    "clicker/internal/domain/entity"
+)
+
+func TestCheckToken(t *testing.T) {
+    now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+    signer, err := clicktoken.NewSigner(map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)}, "k1", time.Hour)
+    if err != nil {
+        t.Fatalf("NewSigner: %v", err)
+    }
+    issue := func() string {
+        value, _, err := signer.Issue(42, "", now)
+        if err != nil {
+            t.Fatalf("Issue: %v", err)
+        }
+        return value
+    }
+    replayed := issue()
+
+    uc := &clickUseCase{tokens: signer, replays: clicktoken.NewReplayCache()}
+    if reason := uc.checkToken(42, replayed, now); reason != "" {
+        t.Fatalf("first use: got %q, want accepted", reason)
+    }
+
+    tests := []struct {
+        name     string
+        bannerID int64
+        value    string
+        at       time.Time
+        want     entity.RejectReason
+    }{
+        {"valid", 42, issue(), now, ""},
+        {"missing", 42, "", now, entity.RejectMissingToken},
+        {"garbage", 42, "abc.def", now, entity.RejectInvalidToken},
+        {"wrong banner", 43, issue(), now, entity.RejectInvalidToken},
+        {"expired", 42, issue(), now.Add(time.Hour), entity.RejectExpiredToken},
+        {"replayed", 42, replayed, now, entity.RejectReplayedToken},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := uc.checkToken(tt.bannerID, tt.value, tt.at); got != tt.want {
+                t.Errorf("checkToken = %q, want %q", got, tt.want)
+            }
+        })
+    }
+
+    if reason := (&clickUseCase{}).checkToken(42, "", now); reason != "" {
+        t.Errorf("without a signer: got %q, want accepted", reason)
+    }
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "strings"
+    "time"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+type conversionUseCase struct {
+    repo   repository.ConversionRepository
+    clicks repository.ClickRepository
+    window time.Duration
+}
+
+// NewConversionUseCase creates the conversion use case. Conversions are
+// only attributed to clicks made at most window before them.
+func NewConversionUseCase(repo repository.ConversionRepository, clicks repository.ClickRepository,
+    window time.Duration) repository.ConversionUseCase {
+    return &conversionUseCase{
+        repo:   repo,
+        clicks: clicks,
+        window: window,
+    }
+}
+
+func (uc *conversionUseCase) Register(ctx context.Context, conversion *entity.Conversion) (*entity.Conversion, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    conversion.ClickID = strings.TrimSpace(conversion.ClickID)
+    conversion.Currency = strings.ToUpper(strings.TrimSpace(conversion.Currency))
+    if err := validateConversion(conversion); err != nil {
+        return nil, err
+    }
+
+    click, err := uc.clicks.GetByClickID(ctx, tenantID, conversion.ClickID)
+    if err != nil {
+        return nil, err
+    }
+    // Filtered clicks are not counted, so neither are their conversions.
+    if click.RejectReason != "" {
+        return nil, entity.ErrInvalidClick
+    }
+
+    conversion.BannerID = click.BannerID
+    conversion.Timestamp = time.Now()
+    if conversion.Timestamp.Sub(click.Timestamp) > uc.window {
+        return nil, entity.ErrConversionOutsideWindow
+    }
+
+    if err := uc.repo.Save(ctx, conversion); err != nil {
+        return nil, err
+    }
+    return conversion, nil
+}
+
+func validateConversion(conversion *entity.Conversion) error {
+    if conversion.ClickID == "" {
+        return fmt.Errorf("%w: click id is required", entity.ErrInvalidConversion)
+    }
+    if math.IsNaN(conversion.Value) || math.IsInf(conversion.Value, 0) || conversion.Value < 0 {
+        return fmt.Errorf("%w: value must be a non-negative number", entity.ErrInvalidConversion)
+    }
+    if len(conversion.Currency) != 3 || strings.Trim(conversion.Currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+        return fmt.Errorf("%w: currency must be a three letter ISO 4217 code", entity.ErrInvalidConversion)
+    }
+    return nil
+}
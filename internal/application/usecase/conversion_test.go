@@ -0,0 +1,72 @@
+package usecase
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+// memoryClicks finds clicks by their click id; the other methods are not
+// used by conversions.
+type memoryClicks struct {
+    repository.ClickRepository
+    clicks map[string]*entity.Click
+}
+
+func (m memoryClicks) GetByClickID(ctx context.Context, tenantID int64, clickID string) (*entity.Click, error) {
+    if click, ok := m.clicks[clickID]; ok {
+        return click, nil
+    }
+    return nil, entity.ErrClickNotFound
+}
+
+// memoryConversions counts saved conversions.
+type memoryConversions struct {
+    saved int
+}
+
+func (m *memoryConversions) Save(ctx context.Context, conversion *entity.Conversion) error {
+    m.saved++
+    conversion.ID = int64(m.saved)
+    return nil
+}
+
+func TestRegisterConversion(t *testing.T) {
+    now := time.Now()
+    clicks := memoryClicks{clicks: map[string]*entity.Click{
+        "counted":  {ClickID: "counted", BannerID: 1, Timestamp: now},
+        "filtered": {ClickID: "filtered", BannerID: 1, Timestamp: now, RejectReason: entity.RejectBot},
+        "old":      {ClickID: "old", BannerID: 1, Timestamp: now.Add(-48 * time.Hour)},
+    }}
+    ctx := auth.WithIdentity(context.Background(), &auth.Identity{TenantID: 1, KeyID: 1})
+
+    tests := []struct {
+        name    string
+        clickID string
+        want    error
+    }{
+        {"counted click", "counted", nil},
+        {"filtered click", "filtered", entity.ErrInvalidClick},
+        {"outside the window", "old", entity.ErrConversionOutsideWindow},
+        {"unknown click", "unknown", entity.ErrClickNotFound},
+        {"no click id", "", entity.ErrInvalidConversion},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            conversions := &memoryConversions{}
+            uc := NewConversionUseCase(conversions, clicks, 24*time.Hour)
+            _, err := uc.Register(ctx, &entity.Conversion{ClickID: tt.clickID, Value: 1, Currency: "usd"})
+            if !errors.Is(err, tt.want) {
+                t.Fatalf("Register = %v, want %v", err, tt.want)
+            }
+            if saved := conversions.saved == 1; saved != (tt.want == nil) {
+                t.Errorf("conversion saved = %v, want %v", saved, tt.want == nil)
+            }
+        })
+    }
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+    "context"
+    "log/slog"
+    "time"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+type impressionUseCase struct {
+    repo        repository.ImpressionRepository
+    banners     *bannerCache
+    impressions *batcher[*entity.Impression]
+    logger      *slog.Logger
+}
+
+func NewImpressionUseCase(repo repository.ImpressionRepository, bannerRepo repository.BannerRepository,
+    batch BatchConfig, logger *slog.Logger) repository.ImpressionUseCase {
+    uc := &impressionUseCase{
+        repo:    repo,
+        banners: newBannerCache(bannerRepo, bannerCacheTTL),
+        logger:  logger,
+    }
+    uc.impressions = newBatcher("impressions", batch, uc.flush)
+    return uc
+}
+
+func (uc *impressionUseCase) Register(ctx context.Context, bannerID int64) error {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return err
+    }
+    if _, _, err := uc.banners.Get(ctx, tenantID, bannerID); err != nil {
+        return err
+    }
+
+    uc.impressions.Add(ctx, &entity.Impression{
+        BannerID:  bannerID,
+        Timestamp: time.Now(),
+    })
+    return nil
+}
+
+func (uc *impressionUseCase) Stalled() error {
+    return uc.impressions.Stalled()
+}
+
+func (uc *impressionUseCase) Saturated() error {
+    return uc.impressions.Saturated()
+}
+
+func (uc *impressionUseCase) BatchSettings() *entity.BatchSettings {
+    return uc.impressions.Settings()
+}
+
+func (uc *impressionUseCase) TuneBatcher(size int, timeout time.Duration) *entity.BatchSettings {
+    return uc.impressions.Tune(size, timeout)
+}
+
+func (uc *impressionUseCase) DrainBatcher(ctx context.Context) error {
+    return uc.impressions.Drain(ctx)
+}
+
+func (uc *impressionUseCase) flush(ctx context.Context, batch []*entity.Impression) error {
+    if err := uc.repo.SaveBatch(ctx, batch); err != nil {
+        uc.logger.ErrorContext(ctx, "Failed to save impressions", "impressions", len(batch), "error", err)
+        return err
+    }
+    return nil
+}
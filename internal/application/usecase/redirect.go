@@ -0,0 +1,76 @@
+package usecase
+
+import (
+    "context"
+    "errors"
+    "log/slog"
+    "strconv"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+)
+
+type redirectUseCase struct {
+    clicks    repository.ClickUseCase
+    banners   *bannerCache
+    redirects entity.DomainAllowlist
+    logger    *slog.Logger
+}
+
+func NewRedirectUseCase(clicks repository.ClickUseCase, bannerRepo repository.BannerRepository,
+    redirects entity.DomainAllowlist, logger *slog.Logger) repository.RedirectUseCase {
+    return &redirectUseCase{
+        clicks:    clicks,
+        banners:   newBannerCache(bannerRepo, bannerCacheTTL),
+        redirects: redirects,
+        logger:    logger,
+    }
+}
+
+func (uc *redirectUseCase) Redirect(ctx context.Context, req *entity.ClickRequest, utm map[string]string) (string, error) {
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return "", err
+    }
+
+    banner, _, err := uc.banners.Get(ctx, tenantID, req.BannerID)
+    if err != nil {
+        return "", err
+    }
+    if banner.TargetURL == "" {
+        return "", entity.ErrNoTargetURL
+    }
+
+    // A click that is not counted, capped ones included, still takes the
+    // user to the landing page; it just carries no click id, as there is no
+    // stored click a postback could refer to.
+    var clickID string
+    result, err := uc.clicks.Counter(ctx, req)
+    switch {
+    case err == nil:
+        if result.Status == entity.ClickAccepted {
+            clickID = result.ClickID
+        }
+    case errors.Is(err, entity.ErrOutOfFlight), errors.Is(err, entity.ErrInvalidClickToken),
+        errors.Is(err, entity.ErrClickTokenExpired):
+        uc.logger.InfoContext(ctx, "Redirecting uncounted click", "banner_id", req.BannerID, "reason", err)
+    default:
+        return "", err
+    }
+
+    values := map[string]string{
+        entity.MacroClickID:  clickID,
+        entity.MacroBannerID: strconv.FormatInt(req.BannerID, 10),
+    }
+    for _, param := range entity.UTMParams {
+        values["{"+param+"}"] = utm[param]
+    }
+
+    // The allowlist may have shrunk since the target URL was set.
+    target := entity.ExpandTargetURL(banner.TargetURL, values)
+    if err := uc.redirects.CheckURL(target); err != nil {
+        return "", err
+    }
+    return target, nil
+}
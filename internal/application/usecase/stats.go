@@ -5,23 +5,81 @@ import (
     "fmt"
     "time"
 
+    "clicker/internal/auth"
     "clicker/internal/domain/entity"
     "clicker/internal/domain/repository"
+    "clicker/internal/tracing"
 )
 
 type statsUseCase struct {
-    repo repository.StatsRepository
+    repo     repository.StatsRepository
+    sketches repository.SketchRepository
 }
 
-func NewStatsUseCase(repo repository.StatsRepository) repository.StatsUseCase {
+func NewStatsUseCase(repo repository.StatsRepository, sketches repository.SketchRepository) repository.StatsUseCase {
     return &statsUseCase{
-        repo: repo,
+        repo:     repo,
+        sketches: sketches,
     }
 }
 
-func (uc *statsUseCase) GetStats(ctx context.Context, bannerID int64, from, to time.Time) ([]*entity.Click, error) {
-    if from.After(to) {
-        return nil, fmt.Errorf("invalid time range: from is after to")
+func (uc *statsUseCase) GetStats(ctx context.Context, bannerID int64, filter repository.StatsFilter) ([]*entity.Click, error) {
+    ctx, span := tracing.Start(ctx, "statsUseCase.GetStats")
+    defer span.End()
+
+    if filter.From.After(filter.To) {
+        return nil, entity.ErrInvalidTimeRange
+    }
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return uc.repo.GetStats(ctx, tenantID, bannerID, filter)
+}
+
+func (uc *statsUseCase) GetRejectedClicks(ctx context.Context, bannerID int64, filter repository.StatsFilter) ([]*entity.RejectedClicks, error) {
+    ctx, span := tracing.Start(ctx, "statsUseCase.GetRejectedClicks")
+    defer span.End()
+
+    if filter.From.After(filter.To) {
+        return nil, entity.ErrInvalidTimeRange
+    }
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return uc.repo.GetRejectedClicks(ctx, tenantID, bannerID, filter)
+}
+
+func (uc *statsUseCase) GetUniqueClicks(ctx context.Context, bannerID int64, filter repository.StatsFilter) (int64, error) {
+    ctx, span := tracing.Start(ctx, "statsUseCase.GetUniqueClicks")
+    defer span.End()
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return 0, err
+    }
+
+    sketch, err := uc.sketches.GetRange(ctx, tenantID, []int64{bannerID}, filter.From, filter.To)
+    if err != nil {
+        return 0, err
+    }
+    return sketch.Estimate(), nil
+}
+
+func (uc *statsUseCase) GetBuckets(ctx context.Context, bannerID int64, filter repository.StatsFilter, bucket time.Duration) ([]*entity.StatsBucket, error) {
+    ctx, span := tracing.Start(ctx, "statsUseCase.GetBuckets")
+    defer span.End()
+
+    if bucket < time.Second {
+        return nil, fmt.Errorf("bucket must be at least one second")
+    }
+
+    tenantID, err := auth.TenantID(ctx)
+    if err != nil {
+        return nil, err
     }
-    return uc.repo.GetStats(ctx, bannerID, from, to)
+    return uc.repo.GetBuckets(ctx, tenantID, bannerID, filter, bucket)
 }
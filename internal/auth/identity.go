@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Identity is the authenticated caller of a request: either an API key
+// (KeyID is set), a dashboard user signed in with a JWT (Subject and Role
+// are set) or the operator (Operator is set), who belongs to no tenant.
+// Publishable is set for publishable API keys, which are only accepted by
+// the pixel and redirect endpoints.
+type Identity struct {
+	TenantID    int64
+	KeyID       int64
+	Subject     string
+	Role        Role
+	Scopes      []Scope
+	Operator    bool
+	Publishable bool
+}
+
+// HasScope reports whether the caller holds scope. ScopeOperator is only
+// held by the operator, whatever scopes a credential lists.
+func (i *Identity) HasScope(scope Scope) bool {
+	if scope == ScopeOperator {
+		return i.Operator
+	}
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// TenantID returns the tenant of the authenticated caller, or
+// ErrUnauthenticated if the context carries no identity.
+func TenantID(ctx context.Context) (int64, error) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return 0, ErrUnauthenticated
+	}
+	return identity.TenantID, nil
+}
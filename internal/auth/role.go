@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+// Role is granted to dashboard users through the "role" claim of their JWT.
+// Each role expands to a fixed set of scopes, so the same per-RPC permission
+// table applies to users and API keys alike.
+type Role string
+
+const (
+	RoleViewer  Role = "viewer"
+	RoleAnalyst Role = "analyst"
+	RoleAdmin   Role = "admin"
+)
+
+var roleScopes = map[Role][]Scope{
+	RoleViewer: {
+		ScopeStatsRead,
+		ScopeCampaignsRead,
+		ScopeBannersRead,
+	},
+	RoleAnalyst: {
+		ScopeStatsRead,
+		ScopeCampaignsRead,
+		ScopeCampaignsWrite,
+		ScopeBannersRead,
+	},
+	RoleAdmin: AllScopes,
+}
+
+// ParseRole validates a role name.
+func ParseRole(name string) (Role, error) {
+	role := Role(name)
+	if _, ok := roleScopes[role]; !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownRole, name)
+	}
+	return role, nil
+}
+
+// Scopes returns the scopes granted by the role.
+func (r Role) Scopes() []Scope {
+	return roleScopes[r]
+}
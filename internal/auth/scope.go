@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrUnknownScope     = errors.New("unknown scope")
+	ErrPublishableScope = errors.New("scope not allowed on publishable keys")
+)
+
+// Scope is a permission granted to a credential.
+type Scope string
+
+const (
+	ScopeClicksWrite      Scope = "clicks:write"
+	ScopeStatsRead        Scope = "stats:read"
+	ScopeCampaignsRead    Scope = "campaigns:read"
+	ScopeCampaignsWrite   Scope = "campaigns:write"
+	ScopeBannersRead      Scope = "banners:read"
+	ScopeBannersWrite     Scope = "banners:write"
+	ScopeKeysManage       Scope = "keys:manage"
+	ScopeClickTokens      Scope = "click-tokens:issue"
+	ScopeImpressionsWrite Scope = "impressions:write"
+	ScopeConversionsWrite Scope = "conversions:write"
+	ScopeAlertsManage     Scope = "alerts:manage"
+
+	// ScopeOperator guards the settings of the whole service, which all
+	// tenants share. It is not in AllScopes, so neither roles nor API keys
+	// can hold it; only the operator token carries it.
+	ScopeOperator Scope = "operator"
+)
+
+// AllScopes lists every scope known to the service.
+var AllScopes = []Scope{
+	ScopeClicksWrite,
+	ScopeStatsRead,
+	ScopeCampaignsRead,
+	ScopeCampaignsWrite,
+	ScopeBannersRead,
+	ScopeBannersWrite,
+	ScopeKeysManage,
+	ScopeClickTokens,
+	ScopeImpressionsWrite,
+	ScopeConversionsWrite,
+	ScopeAlertsManage,
+}
+
+// PublishableScopes are the scopes a publishable key may hold. Publishable
+// keys end up in the HTML of the pages showing banners, so they can do no
+// more than count what visitors see and click.
+var PublishableScopes = []Scope{
+	ScopeClicksWrite,
+	ScopeImpressionsWrite,
+}
+
+// ParseScopes validates raw scope names.
+func ParseScopes(raw []string) ([]Scope, error) {
+	scopes := make([]Scope, 0, len(raw))
+	for _, name := range raw {
+		scope := Scope(name)
+		if !scope.valid() {
+			return nil, fmt.Errorf("%w %q", ErrUnknownScope, name)
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+func (s Scope) valid() bool {
+	for _, known := range AllScopes {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
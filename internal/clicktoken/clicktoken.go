@@ -0,0 +1,160 @@
+// Package clicktoken signs and verifies the tokens embedded in tracking
+// URLs, so that only clicks on URLs handed out by the ad server are counted.
+package clicktoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalid = errors.New("invalid click token")
+	ErrExpired = errors.New("click token expired")
+)
+
+// Token is the signed payload of a tracking URL.
+type Token struct {
+	BannerID  int64
+	Placement string
+	ExpiresAt time.Time
+	Nonce     string
+}
+
+type payload struct {
+	Kid       string `json:"k"`
+	BannerID  int64  `json:"b"`
+	Placement string `json:"p,omitempty"`
+	ExpiresAt int64  `json:"e"`
+	Nonce     string `json:"n"`
+}
+
+// Signer issues tokens with its active key and accepts tokens signed with
+// any of its keys, so a new key can be introduced and the old one retired
+// once the tokens it signed have expired.
+type Signer struct {
+	keys      map[string][]byte
+	activeKid string
+	ttl       time.Duration
+}
+
+// ParseKeys parses "kid:secret" pairs separated by commas, where secret is
+// base64 (standard or URL alphabet) encoded and at least 32 bytes long.
+func ParseKeys(spec string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for i, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		// A malformed entry may be a bare secret, so it is only named by
+		// its position.
+		kid, encoded, ok := strings.Cut(entry, ":")
+		if !ok || kid == "" {
+			return nil, fmt.Errorf("click token key %d is not a kid:secret pair", i+1)
+		}
+		secret, err := decodeSecret(encoded)
+		if err != nil || len(secret) < 32 {
+			return nil, fmt.Errorf("click token key %q must be at least 32 base64 encoded bytes", kid)
+		}
+		keys[kid] = secret
+	}
+	return keys, nil
+}
+
+func NewSigner(keys map[string][]byte, activeKid string, ttl time.Duration) (*Signer, error) {
+	if _, ok := keys[activeKid]; !ok {
+		return nil, fmt.Errorf("active click token key %q is not configured", activeKid)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("click token ttl must be positive")
+	}
+	return &Signer{keys: keys, activeKid: activeKid, ttl: ttl}, nil
+}
+
+// Issue signs a token for a click on the banner at the given placement.
+func (s *Signer) Issue(bannerID int64, placement string, now time.Time) (string, *Token, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	token := &Token{
+		BannerID:  bannerID,
+		Placement: placement,
+		ExpiresAt: now.Add(s.ttl).Truncate(time.Second),
+		Nonce:     base64.RawURLEncoding.EncodeToString(nonce),
+	}
+	body, err := json.Marshal(payload{
+		Kid:       s.activeKid,
+		BannerID:  token.BannerID,
+		Placement: token.Placement,
+		ExpiresAt: token.ExpiresAt.Unix(),
+		Nonce:     token.Nonce,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode click token: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(body)
+	signature := sign(s.keys[s.activeKid], encoded)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signature), token, nil
+}
+
+// Verify checks the signature and expiry of a token. A token whose
+// signature is valid but which has expired is returned along with
+// ErrExpired.
+func (s *Signer) Verify(value string, now time.Time) (*Token, error) {
+	encoded, rawSignature, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, ErrInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(rawSignature)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	body, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, ErrInvalid
+	}
+	key, ok := s.keys[p.Kid]
+	if !ok || !hmac.Equal(signature, sign(key, encoded)) {
+		return nil, ErrInvalid
+	}
+
+	token := &Token{
+		BannerID:  p.BannerID,
+		Placement: p.Placement,
+		ExpiresAt: time.Unix(p.ExpiresAt, 0),
+		Nonce:     p.Nonce,
+	}
+	if !now.Before(token.ExpiresAt) {
+		return token, ErrExpired
+	}
+	return token, nil
+}
+
+func sign(key []byte, encoded string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+func decodeSecret(encoded string) ([]byte, error) {
+	encoded = strings.TrimRight(strings.TrimSpace(encoded), "=")
+	if secret, err := base64.RawStdEncoding.DecodeString(encoded); err == nil {
+		return secret, nil
+	}
+	return base64.RawURLEncoding.DecodeString(encoded)
+}
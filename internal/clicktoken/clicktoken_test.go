@@ -0,0 +1,212 @@
+package clicktoken
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func testKeys(t *testing.T) map[string][]byte {
+	t.Helper()
+	keys, err := ParseKeys("k1:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)) +
+		", k2:" + base64.RawURLEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32)))
+	if err != nil {
+		t.Fatalf("ParseKeys: %v", err)
+	}
+	return keys
+}
+
+func testSigner(t *testing.T, active string) *Signer {
+	t.Helper()
+	s, err := NewSigner(testKeys(t), active, time.Hour)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	return s
+}
+
+func TestIssueVerify(t *testing.T) {
+	s := testSigner(t, "k1")
+	value, issued, err := s.Issue(42, "sidebar", now)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	token, err := s.Verify(value, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if token.BannerID != 42 || token.Placement != "sidebar" || token.Nonce != issued.Nonce || !token.ExpiresAt.Equal(issued.ExpiresAt) {
+		t.Errorf("Verify = %+v, want %+v", token, issued)
+	}
+	if !issued.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("ExpiresAt = %s, want %s", issued.ExpiresAt, now.Add(time.Hour))
+	}
+
+	other, _, err := s.Issue(42, "sidebar", now)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if other == value {
+		t.Error("two tokens for the same banner are identical")
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	s := testSigner(t, "k1")
+	value, _, err := s.Issue(42, "", now)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want error
+	}{
+		{now.Add(time.Hour - time.Second), nil},
+		{now.Add(time.Hour), ErrExpired},
+		{now.Add(48 * time.Hour), ErrExpired},
+	}
+	for _, tt := range tests {
+		token, err := s.Verify(value, tt.at)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Verify at %s: error = %v, want %v", tt.at, err, tt.want)
+		}
+		if token == nil || token.BannerID != 42 {
+			t.Errorf("Verify at %s = %+v, want the token of banner 42", tt.at, token)
+		}
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	s := testSigner(t, "k1")
+	value, _, err := s.Issue(42, "sidebar", now)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	encoded, signature, _ := strings.Cut(value, ".")
+	body, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherBanner := base64.RawURLEncoding.EncodeToString(bytes.Replace(body, []byte(`"b":42`), []byte(`"b":43`), 1))
+	otherKid := base64.RawURLEncoding.EncodeToString(bytes.Replace(body, []byte(`"k":"k1"`), []byte(`"k":"k2"`), 1))
+	unknownKid := base64.RawURLEncoding.EncodeToString(bytes.Replace(body, []byte(`"k":"k1"`), []byte(`"k":"k9"`), 1))
+	flipped := []byte(signature)
+	flipped[0] ^= 1
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"empty", ""},
+		{"no signature", encoded},
+		{"changed banner", otherBanner + "." + signature},
+		{"other key id", otherKid + "." + signature},
+		{"unknown key id", unknownKid + "." + signature},
+		{"changed signature", encoded + "." + string(flipped)},
+		{"truncated signature", encoded + "." + signature[:10]},
+		{"not base64", "!!!." + signature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Verify(tt.value, now); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Verify = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestVerifyKeyRotation(t *testing.T) {
+	old := testSigner(t, "k1")
+	value, _, err := old.Issue(42, "", now)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if _, err := testSigner(t, "k2").Verify(value, now); err != nil {
+		t.Errorf("Verify with rotated signer: %v", err)
+	}
+
+	keys := testKeys(t)
+	delete(keys, "k1")
+	retired, err := NewSigner(keys, "k2", time.Hour)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	if _, err := retired.Verify(value, now); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Verify after retiring the key = %v, want ErrInvalid", err)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	tests := []struct {
+		spec    string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"k1:" + secret, 1, false},
+		{"k1:" + secret + ", k2:" + strings.TrimRight(secret, "=") + ",", 2, false},
+		{"k1", 0, true},
+		{":" + secret, 0, true},
+		{"k1:" + base64.StdEncoding.EncodeToString(make([]byte, 31)), 0, true},
+		{"k1:not base64!", 0, true},
+	}
+	for _, tt := range tests {
+		keys, err := ParseKeys(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseKeys(%q) error = %v, want error %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if len(keys) != tt.want {
+			t.Errorf("ParseKeys(%q) = %d keys, want %d", tt.spec, len(keys), tt.want)
+		}
+	}
+}
+
+func TestParseKeysHidesSecrets(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	_, err := ParseKeys("k1:" + secret + "," + secret)
+	if err == nil || strings.Contains(err.Error(), secret) || !strings.Contains(err.Error(), "key 2") {
+		t.Errorf("ParseKeys = %v, want an error naming key 2 without its secret", err)
+	}
+}
+
+func TestNewSignerErrors(t *testing.T) {
+	keys := testKeys(t)
+	if _, err := NewSigner(keys, "k9", time.Hour); err == nil {
+		t.Error("unknown active key: expected an error")
+	}
+	if _, err := NewSigner(keys, "k1", 0); err == nil {
+		t.Error("zero ttl: expected an error")
+	}
+}
+
+func TestReplayCache(t *testing.T) {
+	c := NewReplayCache()
+	first := &Token{Nonce: "a", ExpiresAt: now.Add(time.Hour)}
+	second := &Token{Nonce: "b", ExpiresAt: now.Add(time.Hour)}
+
+	if !c.Use(first, now) {
+		t.Fatal("first use was refused")
+	}
+	if c.Use(first, now.Add(time.Minute)) {
+		t.Fatal("replay was accepted")
+	}
+	if !c.Use(second, now.Add(time.Minute)) {
+		t.Fatal("another token was refused")
+	}
+
+	later := now.Add(2 * time.Hour)
+	c.Use(&Token{Nonce: "c", ExpiresAt: later.Add(time.Hour)}, later)
+	if _, ok := c.seen["a"]; ok {
+		t.Error("expired nonce was not swept")
+	}
+}
@@ -0,0 +1,40 @@
+package clicktoken
+
+import (
+	"sync"
+	"time"
+)
+
+// ReplayCache remembers the nonces of tokens that were already used until
+// the tokens expire. It is local to the process, so with several replicas a
+// token can be used at most once per replica.
+type ReplayCache struct {
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewReplayCache() *ReplayCache {
+	return &ReplayCache{seen: make(map[string]time.Time)}
+}
+
+// Use marks the token's nonce as used and reports whether it was unused.
+func (c *ReplayCache) Use(token *Token, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastSweep) > time.Minute {
+		c.lastSweep = now
+		for nonce, expiresAt := range c.seen {
+			if !now.Before(expiresAt) {
+				delete(c.seen, nonce)
+			}
+		}
+	}
+
+	if _, ok := c.seen[token.Nonce]; ok {
+		return false
+	}
+	c.seen[token.Nonce] = token.ExpiresAt
+	return true
+}
@@ -0,0 +1,115 @@
+// Package clientip finds the address of the client behind the proxies in
+// front of the service. X-Forwarded-For is only believed as far as it was
+// written by trusted proxies: every proxy appends the address it received
+// the request from, so the header is read from the right and the first
+// address that is not a trusted proxy is the client. Whatever the client
+// put into the header itself is never used.
+package clientip
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ForwardedForHeader is the header proxies record the client address in.
+const ForwardedForHeader = "X-Forwarded-For"
+
+var trusted = struct {
+	sync.RWMutex
+	nets []*net.IPNet
+}{}
+
+// ParseTrustedProxies parses a comma separated list of addresses and CIDR
+// ranges. An empty list trusts no proxy besides loopback.
+func ParseTrustedProxies(list string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid proxy address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy range %q", entry)
+		}
+		nets = append(nets, network)
+	}
+	return nets, nil
+}
+
+// SetTrustedProxies sets the proxies whose X-Forwarded-For entries are
+// believed. Loopback is always trusted, as the REST gateway relays calls to
+// the gRPC server over it.
+func SetTrustedProxies(nets []*net.IPNet) {
+	trusted.Lock()
+	defer trusted.Unlock()
+	trusted.nets = nets
+}
+
+// Resolve returns the client address for a connection from remoteAddr, a
+// host with or without port, that carried the given X-Forwarded-For values.
+func Resolve(remoteAddr string, forwarded []string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	if !isTrusted(host) {
+		return host
+	}
+
+	var hops []string
+	for _, value := range forwarded {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	client := host
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		client = hop
+		if !isTrusted(hop) {
+			break
+		}
+	}
+	return client
+}
+
+// FromRequest returns the client address of an HTTP request.
+func FromRequest(r *http.Request) string {
+	return Resolve(r.RemoteAddr, r.Header.Values(ForwardedForHeader))
+}
+
+func isTrusted(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+
+	trusted.RLock()
+	defer trusted.RUnlock()
+	for _, network := range trusted.nets {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package clientip
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	nets, err := ParseTrustedProxies("10.0.0.0/8, 192.0.2.7")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies: %v", err)
+	}
+	SetTrustedProxies(nets)
+	t.Cleanup(func() { SetTrustedProxies(nil) })
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		want      string
+	}{
+		{"direct client", "203.0.113.5:4000", nil, "203.0.113.5"},
+		{"untrusted peer ignores header", "203.0.113.5:4000", []string{"198.51.100.1"}, "203.0.113.5"},
+		{"gateway without header", "127.0.0.1:5000", nil, "127.0.0.1"},
+		{"gateway appends client", "127.0.0.1:5000", []string{"203.0.113.5"}, "203.0.113.5"},
+		{"spoofed entry is skipped", "127.0.0.1:5000", []string{"198.51.100.1, 203.0.113.5"}, "203.0.113.5"},
+		{"through trusted proxies", "127.0.0.1:5000", []string{"198.51.100.1, 203.0.113.5, 10.1.2.3, 192.0.2.7"}, "203.0.113.5"},
+		{"repeated headers", "[::1]:5000", []string{"198.51.100.1", "203.0.113.5, 10.1.2.3"}, "203.0.113.5"},
+		{"only trusted hops", "127.0.0.1:5000", []string{"10.1.2.3, 10.4.5.6"}, "10.1.2.3"},
+		{"blank entries", "127.0.0.1:5000", []string{"203.0.113.5, ,"}, "203.0.113.5"},
+		{"remote without port", "203.0.113.5", nil, "203.0.113.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resolve(tt.remote, tt.forwarded); got != tt.want {
+				t.Errorf("Resolve(%q, %q) = %q, want %q", tt.remote, tt.forwarded, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/c/1", nil)
+	r.RemoteAddr = "127.0.0.1:5000"
+	r.Header.Add(ForwardedForHeader, "198.51.100.1")
+	r.Header.Add(ForwardedForHeader, "203.0.113.5")
+
+	if got := FromRequest(r); got != "203.0.113.5" {
+		t.Errorf("FromRequest = %q, want 203.0.113.5", got)
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"10.0.0.0/8", 1, false},
+		{"10.0.0.1, 2001:db8::/32,", 2, false},
+		{"not-an-ip", 0, true},
+		{"10.0.0.0/33", 0, true},
+	}
+	for _, tt := range tests {
+		nets, err := ParseTrustedProxies(tt.list)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTrustedProxies(%q) error = %v, want error %v", tt.list, err, tt.wantErr)
+			continue
+		}
+		if len(nets) != tt.want {
+			t.Errorf("ParseTrustedProxies(%q) = %d ranges, want %d", tt.list, len(nets), tt.want)
+		}
+	}
+}
@@ -1,57 +1,329 @@
 package config
 
 import (
+    "errors"
+    "flag"
     "fmt"
+    "io/fs"
     "os"
+    "strings"
 
     "github.com/joho/godotenv"
 )
 
+// Config holds the settings of the application. Every setting is read, in
+// increasing order of precedence, from its default, the optional config
+// file, the environment (including a ".env" file) and the command line.
 type Config struct {
+    Mode string
+
     PostgresHost     string
     PostgresPort     string
     PostgresUser     string
     PostgresPassword string
     PostgresDB       string
+    PostgresSSLMode  string
+    PostgresReadDSN  string
+
+    PostgresMaxConns         string
+    PostgresMinConns         string
+    PostgresMaxConnLifetime  string
+    PostgresMaxConnIdleTime  string
+    PostgresStatementTimeout string
+
+    BatchSize          string
+    BatchTimeout       string
+    BatchQueueCapacity string
+
+    PostgresConnectAttempts   string
+    PostgresConnectBackoff    string
+    PostgresConnectMaxBackoff string
+
+    AutoMigrate string
 
     RestHost string
     RestPort string
 
     GrpcHost string
     GrpcPort string
+
+    TrustedProxies string
+
+    ShutdownTimeout string
+
+    FlightPolicy string
+
+    JWKSFile    string
+    JWTIssuer   string
+    JWTAudience string
+
+    OperatorToken string
+
+    RateLimits string
+
+    ClickTokenKeys      string
+    ClickTokenActiveKey string
+    ClickTokenTTL       string
+
+    BotUserAgents        string
+    ClickVelocityLimit   string
+    ClickVelocityWindow  string
+    ClickDuplicateWindow string
+    DatacenterRangesFile string
+
+    TLSCertFile        string
+    TLSKeyFile         string
+    TLSClientCAFile    string
+    TLSCAFile          string
+    TLSServerName      string
+    GatewayTLSCertFile string
+    GatewayTLSKeyFile  string
+
+    RedirectAllowedDomains string
+
+    ConversionWindow string
+
+    AlertInterval       string
+    AlertWebhookSecret  string
+    AlertWebhookRetries string
+
+    MetricsBannerLimit string
+
+    TracingExporter    string
+    TracingFile        string
+    TracingSampleRatio string
+
+    LogFormat string
+    LogLevel  string
+
+    // sources records where every setting came from, by name.
+    sources map[string]string
+}
+
+// Sources of a setting, as reported by Print.
+const (
+    sourceDefault = "default"
+    sourceFile    = "file"
+    sourceEnv     = "env"
+    sourceFlag    = "flag"
+)
+
+// ConfigFileEnv names the environment variable that points to the config
+// file when no --config flag is given.
+const ConfigFileEnv = "CONFIG_FILE"
+
+// field describes one setting. Its name is the environment variable; the
+// config file key is the name in lower case and the flag the name in lower
+// case with dashes. Secret settings may also be read from the file named by
+// "<name>_FILE" and are redacted by Print.
+type field struct {
+    name     string
+    value    *string
+    def      string
+    usage    string
+    secret   bool
+    validate func(string) error
+}
+
+func (c *Config) fields() []field {
+    return []field{
+        {"MODE", &c.Mode, "all", "parts of the service to run: all, ingest, query or worker", false, oneOf("all", "ingest", "query", "worker")},
+
+        {"POSTGRES_HOST", &c.PostgresHost, "localhost", "PostgreSQL host", false, notEmpty},
+        {"POSTGRES_PORT", &c.PostgresPort, "5432", "PostgreSQL port", false, port},
+        {"POSTGRES_USER", &c.PostgresUser, "clicks_user", "PostgreSQL user", false, notEmpty},
+        {"POSTGRES_PASSWORD", &c.PostgresPassword, "clicks_password", "PostgreSQL password", true, nil},
+        {"POSTGRES_DB", &c.PostgresDB, "clicks_db", "PostgreSQL database", false, notEmpty},
+        {"POSTGRES_SSLMODE", &c.PostgresSSLMode, "disable", "PostgreSQL sslmode", false,
+            oneOf("disable", "allow", "prefer", "require", "verify-ca", "verify-full")},
+        {"POSTGRES_READ_DSN", &c.PostgresReadDSN, "", "DSN of a read replica for stats queries and alert evaluation, the primary when empty", true, nil},
+
+        {"POSTGRES_MAX_CONNS", &c.PostgresMaxConns, "10", "maximum size of the connection pool", false, positiveInt},
+        {"POSTGRES_MIN_CONNS", &c.PostgresMinConns, "0", "connections the pool keeps open when idle", false, nonNegativeInt},
+        {"POSTGRES_MAX_CONN_LIFETIME", &c.PostgresMaxConnLifetime, "1h", "age after which a connection is closed", false, positiveDuration},
+        {"POSTGRES_MAX_CONN_IDLE_TIME", &c.PostgresMaxConnIdleTime, "30m", "idle time after which a connection is closed", false, positiveDuration},
+        {"POSTGRES_STATEMENT_TIMEOUT", &c.PostgresStatementTimeout, "0", "time after which statements are canceled, 0 for none", false, nonNegativeDuration},
+
+        {"BATCH_SIZE", &c.BatchSize, "100", "clicks or impressions after which a batch is written", false, positiveInt},
+        {"BATCH_TIMEOUT", &c.BatchTimeout, "1s", "longest wait for a full batch", false, positiveDuration},
+        {"BATCH_QUEUE_CAPACITY", &c.BatchQueueCapacity, "1000", "items that can wait to be batched", false, positiveInt},
+
+        {"POSTGRES_CONNECT_ATTEMPTS", &c.PostgresConnectAttempts, "0", "attempts to reach the database on startup, 0 for no limit", false, nonNegativeInt},
+        {"POSTGRES_CONNECT_BACKOFF", &c.PostgresConnectBackoff, "1s", "delay before the first retry, doubled after every further attempt", false, positiveDuration},
+        {"POSTGRES_CONNECT_MAX_BACKOFF", &c.PostgresConnectMaxBackoff, "30s", "longest delay between attempts", false, positiveDuration},
+
+        {"AUTO_MIGRATE", &c.AutoMigrate, "false", "apply pending migrations on startup", false, boolean},
+
+        {"REST_HOST", &c.RestHost, "0.0.0.0", "address the REST listener binds to", false, nil},
+        {"REST_PORT", &c.RestPort, "8080", "port of the REST listener", false, port},
+
+        {"GRPC_HOST", &c.GrpcHost, "0.0.0.0", "address the gRPC listener binds to", false, nil},
+        {"GRPC_PORT", &c.GrpcPort, "50051", "port of the gRPC listener", false, port},
+
+        {"TRUSTED_PROXIES", &c.TrustedProxies, "", "comma separated addresses and CIDR ranges of proxies whose X-Forwarded-For entries are believed", false, proxyList},
+
+        {"SHUTDOWN_TIMEOUT", &c.ShutdownTimeout, "15s", "time given to drain requests and queued writes on shutdown", false, positiveDuration},
+
+        {"FLIGHT_POLICY", &c.FlightPolicy, "flag", "handling of clicks outside a banner's flight: flag or reject", false, oneOf("flag", "reject")},
+
+        {"JWT_JWKS_FILE", &c.JWKSFile, "", "JWKS file with the keys of dashboard JWTs", false, readableFile},
+        {"JWT_ISSUER", &c.JWTIssuer, "", "required issuer of JWTs", false, nil},
+        {"JWT_AUDIENCE", &c.JWTAudience, "", "required audience of JWTs", false, nil},
+
+        {"OPERATOR_TOKEN", &c.OperatorToken, "", "credential for the service-wide admin API, which tenants cannot be granted", true, nil},
+
+        {"RATE_LIMITS", &c.RateLimits, "Counter=100:200:key,500:1000:ip;Pixel=20:50:ip;Redirect=5:20:ip;Stats=5:10:tenant,20:40:ip;CampaignStats=5:10:tenant,20:40:ip;*=50:100:key,100:200:ip", "rate limits per RPC and HTTP endpoint", false, rateLimits},
+
+        {"CLICK_TOKEN_KEYS", &c.ClickTokenKeys, "", "click token keys as kid:secret pairs", true, clickTokenKeys},
+        {"CLICK_TOKEN_ACTIVE_KEY", &c.ClickTokenActiveKey, "", "kid of the key that signs click tokens", false, nil},
+        {"CLICK_TOKEN_TTL", &c.ClickTokenTTL, "24h", "lifetime of click tokens", false, positiveDuration},
+
+        {"BOT_USER_AGENTS", &c.BotUserAgents, "bot,crawler,spider,slurp,headlesschrome,phantomjs", "user agent patterns of bots", false, nil},
+        {"CLICK_VELOCITY_LIMIT", &c.ClickVelocityLimit, "30", "clicks per IP and banner within the velocity window, 0 to disable", false, nonNegativeInt},
+        {"CLICK_VELOCITY_WINDOW", &c.ClickVelocityWindow, "1m", "window of the velocity filter", false, nonNegativeDuration},
+        {"CLICK_DUPLICATE_WINDOW", &c.ClickDuplicateWindow, "10s", "window of the duplicate filter, 0 to disable", false, nonNegativeDuration},
+        {"DATACENTER_RANGES_FILE", &c.DatacenterRangesFile, "", "file of datacenter CIDR ranges", false, readableFile},
+
+        {"TLS_CERT_FILE", &c.TLSCertFile, "", "server certificate", false, readableFile},
+        {"TLS_KEY_FILE", &c.TLSKeyFile, "", "server key", false, readableFile},
+        {"TLS_CLIENT_CA_FILE", &c.TLSClientCAFile, "", "CAs of gRPC client certificates, enables mTLS", false, readableFile},
+        {"TLS_CA_FILE", &c.TLSCAFile, "", "CAs the gateway verifies the gRPC server against", false, readableFile},
+        {"TLS_SERVER_NAME", &c.TLSServerName, "localhost", "name the gateway expects in the gRPC server certificate", false, nil},
+        {"GATEWAY_TLS_CERT_FILE", &c.GatewayTLSCertFile, "", "client certificate of the gateway", false, readableFile},
+        {"GATEWAY_TLS_KEY_FILE", &c.GatewayTLSKeyFile, "", "client key of the gateway", false, readableFile},
+
+        {"REDIRECT_ALLOWED_DOMAINS", &c.RedirectAllowedDomains, "", "domains banners may redirect to", false, domainList},
+
+        {"CONVERSION_ATTRIBUTION_WINDOW", &c.ConversionWindow, "720h", "how long after a click conversions are attributed to it", false, positiveDuration},
+
+        {"ALERT_EVALUATION_INTERVAL", &c.AlertInterval, "1m", "how often alert rules are evaluated", false, positiveDuration},
+        {"ALERT_WEBHOOK_SECRET", &c.AlertWebhookSecret, "", "secret that signs alert webhooks", true, nil},
+        {"ALERT_WEBHOOK_RETRIES", &c.AlertWebhookRetries, "5", "retries of failed alert webhooks", false, nonNegativeInt},
+
+        {"METRICS_BANNER_LIMIT", &c.MetricsBannerLimit, "100", "banners with a metrics label of their own", false, nonNegativeInt},
+
+        {"TRACING_EXPORTER", &c.TracingExporter, "none", "trace exporter: none, otlp or file", false, oneOf("none", "otlp", "file")},
+        {"TRACING_FILE", &c.TracingFile, "traces.json", "file the file exporter writes to", false, nil},
+        {"TRACING_SAMPLE_RATIO", &c.TracingSampleRatio, "1", "share of new traces that are recorded", false, ratio},
+
+        {"LOG_FORMAT", &c.LogFormat, "text", "log format: text or json", false, oneOf("text", "json")},
+        {"LOG_LEVEL", &c.LogLevel, "info", "lowest level logged: debug, info, warn or error", false, oneOf("debug", "info", "warn", "error")},
+    }
 }
 
-func New() (*Config, error) {
-    if err := godotenv.Load(); err != nil {
+// Load builds the configuration from the defaults, the config file, the
+// environment and the flags in args, and validates it. All invalid settings
+// are reported together.
+func Load(args []string) (*Config, error) {
+    c := &Config{sources: make(map[string]string)}
+    fields := c.fields()
+
+    flags := flag.NewFlagSet("clicks-counter", flag.ContinueOnError)
+    configFile := flags.String("config", "", "YAML or TOML config file (env "+ConfigFileEnv+")")
+    flagValues := make(map[string]*string, len(fields))
+    for _, f := range fields {
+        flagValues[f.name] = flags.String(flagName(f.name), "", fmt.Sprintf("%s (default %q)", f.usage, f.def))
+    }
+    if err := flags.Parse(args); err != nil {
+        return nil, err
+    }
+    if flags.NArg() > 0 {
+        return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+    }
+    setFlags := make(map[string]bool)
+    flags.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+    if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
         return nil, fmt.Errorf("error loading .env file: %w", err)
     }
 
-    return &Config{
-        PostgresHost:     getEnv("POSTGRES_HOST", "localhost"),
-        PostgresPort:     getEnv("POSTGRES_PORT", "5432"),
-        PostgresUser:     getEnv("POSTGRES_USER", "clicks_user"),
-        PostgresPassword: getEnv("POSTGRES_PASSWORD", "clicks_password"),
-        PostgresDB:       getEnv("POSTGRES_DB", "clicks_db"),
+    path := *configFile
+    if !setFlags["config"] {
+        path = os.Getenv(ConfigFileEnv)
+    }
+    var file map[string]string
+    if path != "" {
+        var err error
+        if file, err = readFile(path, fields); err != nil {
+            return nil, err
+        }
+    }
+    fromFile := func(key string) (string, bool) {
+        value, ok := file[strings.ToLower(key)]
+        return value, ok
+    }
+
+    var errs []error
+    for _, f := range fields {
+        value, source := f.def, sourceDefault
+        if v, ok, err := lookup(fromFile, f); err != nil {
+            errs = append(errs, err)
+        } else if ok {
+            value, source = v, sourceFile
+        }
+        if v, ok, err := lookup(os.LookupEnv, f); err != nil {
+            errs = append(errs, err)
+        } else if ok {
+            value, source = v, sourceEnv
+        }
+        if setFlags[flagName(f.name)] {
+            value, source = *flagValues[f.name], sourceFlag
+        }
+        *f.value = value
+        c.sources[f.name] = source
+    }
+    if len(errs) > 0 {
+        return nil, errors.Join(errs...)
+    }
+
+    if err := c.Validate(); err != nil {
+        return nil, err
+    }
+    return c, nil
+}
 
-        RestHost: getEnv("REST_HOST", "0.0.0.0"),
-        RestPort: getEnv("REST_PORT", "8080"),
+// lookup reads the setting of f from one source, following the "_FILE"
+// indirection of secret settings.
+func lookup(source func(string) (string, bool), f field) (string, bool, error) {
+    value, ok := source(f.name)
+    if !f.secret {
+        return value, ok, nil
+    }
+    path, indirect := source(f.name + "_FILE")
+    switch {
+    case ok && indirect:
+        return "", false, fmt.Errorf("%s: both %s and %s_FILE are set", f.name, f.name, f.name)
+    case indirect:
+        raw, err := os.ReadFile(path)
+        if err != nil {
+            return "", false, fmt.Errorf("%s: %w", f.name, err)
+        }
+        return strings.TrimRight(string(raw), "\r\n"), true, nil
+    }
+    return value, ok, nil
+}
 
-        GrpcHost: getEnv("GRPC_HOST", "0.0.0.0"),
-        GrpcPort: getEnv("GRPC_PORT", "50051"),
-    }, nil
+func flagName(name string) string {
+    return strings.ReplaceAll(strings.ToLower(name), "_", "-")
 }
 
 func (c *Config) GetPostgresDSN() string {
     return fmt.Sprintf(
-        "host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+        "host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
         c.PostgresHost,
         c.PostgresPort,
         c.PostgresUser,
         c.PostgresPassword,
         c.PostgresDB,
+        c.PostgresSSLMode,
     )
 }
 
+// TLSEnabled reports whether the gRPC and REST listeners serve TLS.
+func (c *Config) TLSEnabled() bool {
+    return c.TLSCertFile != ""
+}
+
 func (c *Config) GetRestAddress() string {
     return fmt.Sprintf("%s:%s", c.RestHost, c.RestPort)
 }
@@ -60,9 +332,3 @@ func (c *Config) GetGrpcAddress() string {
     return fmt.Sprintf("%s:%s", c.GrpcHost, c.GrpcPort)
 }
 
-func getEnv(key, defaultValue string) string {
-    if value, exists := os.LookupEnv(key); exists {
-        return value
-    }
-    return defaultValue
-}
@@ -0,0 +1,171 @@
+package config
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+// defaults returns a configuration holding the default of every setting.
+func defaults() *Config {
+    c := &Config{sources: make(map[string]string)}
+    for _, f := range c.fields() {
+        *f.value = f.def
+    }
+    return c
+}
+
+func TestValidateDefaults(t *testing.T) {
+    if err := defaults().Validate(); err != nil {
+        t.Fatalf("Validate of the defaults: %v", err)
+    }
+}
+
+func TestValidate(t *testing.T) {
+    key := "k1:" + strings.Repeat("A", 43)
+    tests := []struct {
+        name   string
+        change func(c *Config)
+        want   string
+    }{
+        {"unknown mode", func(c *Config) { c.Mode = "both" }, "MODE:"},
+        {"empty host", func(c *Config) { c.PostgresHost = " " }, "POSTGRES_HOST: must not be empty"},
+        {"port out of range", func(c *Config) { c.RestPort = "70000" }, "REST_PORT: invalid port"},
+        {"zero pool", func(c *Config) { c.PostgresMaxConns = "0" }, "POSTGRES_MAX_CONNS:"},
+        {"negative min conns", func(c *Config) { c.PostgresMinConns = "-1" }, "POSTGRES_MIN_CONNS:"},
+        {"bad duration", func(c *Config) { c.ClickTokenTTL = "soon" }, "CLICK_TOKEN_TTL:"},
+        {"zero duration", func(c *Config) { c.ShutdownTimeout = "0s" }, "SHUTDOWN_TIMEOUT:"},
+        {"negative window", func(c *Config) { c.ClickDuplicateWindow = "-1s" }, "CLICK_DUPLICATE_WINDOW:"},
+        {"not a boolean", func(c *Config) { c.AutoMigrate = "sometimes" }, "AUTO_MIGRATE:"},
+        {"ratio above one", func(c *Config) { c.TracingSampleRatio = "1.5" }, "TRACING_SAMPLE_RATIO:"},
+        {"bad proxy", func(c *Config) { c.TrustedProxies = "10.0.0.0/8, proxy" }, "TRUSTED_PROXIES:"},
+        {"cert without key", func(c *Config) { c.TLSCertFile = "server.crt" }, "TLS_CERT_FILE and TLS_KEY_FILE"},
+        {"gateway key without cert", func(c *Config) { c.GatewayTLSKeyFile = "gw.key" }, "GATEWAY_TLS_CERT_FILE and GATEWAY_TLS_KEY_FILE"},
+        {"client CA without certs", func(c *Config) { c.TLSClientCAFile = "ca.crt" }, "TLS_CLIENT_CA_FILE requires"},
+        {"min above max conns", func(c *Config) { c.PostgresMinConns = "20" }, "POSTGRES_MIN_CONNS must not exceed"},
+        {"max below backoff", func(c *Config) { c.PostgresConnectMaxBackoff = "500ms" }, "POSTGRES_CONNECT_MAX_BACKOFF must not be below"},
+        {"batch above queue", func(c *Config) { c.BatchSize = "2000" }, "BATCH_SIZE must not exceed"},
+        {"keys without active key", func(c *Config) { c.ClickTokenKeys = key }, "CLICK_TOKEN_ACTIVE_KEY is required"},
+        {"short click token key", func(c *Config) { c.ClickTokenKeys, c.ClickTokenActiveKey = "k1:secret", "k1" }, "CLICK_TOKEN_KEYS:"},
+        {"unknown active key", func(c *Config) { c.ClickTokenKeys, c.ClickTokenActiveKey = key, "k2" }, "CLICK_TOKEN_ACTIVE_KEY \"k2\" is not one of"},
+        {"active key without keys", func(c *Config) { c.ClickTokenActiveKey = "k1" }, "CLICK_TOKEN_ACTIVE_KEY \"k1\" is not one of"},
+        {"bad rate limit", func(c *Config) { c.RateLimits = "Counter=100:200" }, "RATE_LIMITS:"},
+        {"missing jwks", func(c *Config) { c.JWKSFile = "/nonexistent/jwks.json" }, "JWT_JWKS_FILE:"},
+        {"missing ranges", func(c *Config) { c.DatacenterRangesFile = "/nonexistent/ranges.txt" }, "DATACENTER_RANGES_FILE:"},
+        {"url as domain", func(c *Config) { c.RedirectAllowedDomains = "example.com, https://ads.example.com" }, "REDIRECT_ALLOWED_DOMAINS:"},
+        {"file exporter without file", func(c *Config) { c.TracingExporter, c.TracingFile = "file", "" }, "TRACING_FILE is required"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            c := defaults()
+            tt.change(c)
+            err := c.Validate()
+            if err == nil || !strings.Contains(err.Error(), tt.want) {
+                t.Errorf("Validate = %v, want an error containing %q", err, tt.want)
+            }
+        })
+    }
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+    c := defaults()
+    c.RestPort = "0"
+    c.GrpcPort = "x"
+    c.LogLevel = "trace"
+
+    err := c.Validate()
+    if err == nil {
+        t.Fatal("Validate = nil, want errors")
+    }
+    for _, name := range []string{"REST_PORT", "GRPC_PORT", "LOG_LEVEL"} {
+        if !strings.Contains(err.Error(), name) {
+            t.Errorf("Validate = %q, want %s reported", err, name)
+        }
+    }
+}
+
+func TestLoadPrecedence(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "clicker.yaml")
+    writeFile(t, path, "rest_port: 8081\ngrpc_port: 50052\njwt_issuer: file\n")
+    t.Setenv(ConfigFileEnv, path)
+    t.Setenv("GRPC_PORT", "50053")
+    t.Setenv("JWT_ISSUER", "env")
+
+    c, err := Load([]string{"-jwt-issuer", "flag"})
+    if err != nil {
+        t.Fatalf("Load: %v", err)
+    }
+    tests := []struct {
+        name, got, want, source string
+    }{
+        {"LOG_LEVEL", c.LogLevel, "info", sourceDefault},
+        {"REST_PORT", c.RestPort, "8081", sourceFile},
+        {"GRPC_PORT", c.GrpcPort, "50053", sourceEnv},
+        {"JWT_ISSUER", c.JWTIssuer, "flag", sourceFlag},
+    }
+    for _, tt := range tests {
+        if tt.got != tt.want || c.source(tt.name) != tt.source {
+            t.Errorf("%s = %q from %s, want %q from %s", tt.name, tt.got, c.source(tt.name), tt.want, tt.source)
+        }
+    }
+}
+
+func TestLoadSecretFile(t *testing.T) {
+    t.Setenv(ConfigFileEnv, "")
+    secret := filepath.Join(t.TempDir(), "password")
+    writeFile(t, secret, "s3cret\n")
+    t.Setenv("POSTGRES_PASSWORD_FILE", secret)
+
+    c, err := Load(nil)
+    if err != nil {
+        t.Fatalf("Load: %v", err)
+    }
+    if c.PostgresPassword != "s3cret" {
+        t.Errorf("POSTGRES_PASSWORD = %q, want the file's content", c.PostgresPassword)
+    }
+
+    t.Setenv("POSTGRES_PASSWORD", "other")
+    if _, err := Load(nil); err == nil || !strings.Contains(err.Error(), "both POSTGRES_PASSWORD and POSTGRES_PASSWORD_FILE") {
+        t.Errorf("Load = %v, want a conflict error", err)
+    }
+}
+
+func TestLoadErrors(t *testing.T) {
+    dir := t.TempDir()
+    unknown := filepath.Join(dir, "unknown.yaml")
+    writeFile(t, unknown, "rest_prot: 8081\n")
+    nested := filepath.Join(dir, "nested.toml")
+    writeFile(t, nested, "[rest_port]\nvalue = 1\n")
+    format := filepath.Join(dir, "clicker.json")
+    writeFile(t, format, "{}")
+
+    tests := []struct {
+        name string
+        file string
+        args []string
+        want string
+    }{
+        {"unknown key", unknown, nil, "unknown keys rest_prot"},
+        {"nested table", nested, nil, "nested tables are not supported"},
+        {"unsupported format", format, nil, "unsupported format"},
+        {"missing file", filepath.Join(dir, "missing.yaml"), nil, "failed to read config file"},
+        {"invalid value", "", []string{"-rest-port", "0"}, "REST_PORT: invalid port"},
+        {"extra arguments", "", []string{"serve"}, "unexpected arguments: serve"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            t.Setenv(ConfigFileEnv, tt.file)
+            if _, err := Load(tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+                t.Errorf("Load = %v, want an error containing %q", err, tt.want)
+            }
+        })
+    }
+}
+
+func writeFile(t *testing.T, path, content string) {
+    t.Helper()
+    if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+        t.Fatal(err)
+    }
+}
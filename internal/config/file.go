@@ -0,0 +1,86 @@
+package config
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+
+    "github.com/BurntSushi/toml"
+    "gopkg.in/yaml.v3"
+)
+
+// readFile reads a flat YAML or TOML config file, chosen by its extension,
+// whose keys are the setting names in lower case, such as "rest_port".
+// Secret settings may instead name a file holding the value, as in
+// "postgres_password_file". Lists are joined with commas.
+func readFile(path string, fields []field) (map[string]string, error) {
+    raw, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read config file: %w", err)
+    }
+
+    var values map[string]interface{}
+    switch strings.ToLower(filepath.Ext(path)) {
+    case ".yaml", ".yml":
+        err = yaml.Unmarshal(raw, &values)
+    case ".toml":
+        err = toml.Unmarshal(raw, &values)
+    default:
+        return nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+    }
+
+    known := make(map[string]bool, len(fields))
+    for _, f := range fields {
+        known[strings.ToLower(f.name)] = true
+        if f.secret {
+            known[strings.ToLower(f.name)+"_file"] = true
+        }
+    }
+
+    var unknown []string
+    settings := make(map[string]string, len(values))
+    for key, value := range values {
+        if !known[key] {
+            unknown = append(unknown, key)
+            continue
+        }
+        s, err := scalar(value)
+        if err != nil {
+            return nil, fmt.Errorf("config file %s: %s: %w", path, key, err)
+        }
+        settings[key] = s
+    }
+    if len(unknown) > 0 {
+        sort.Strings(unknown)
+        return nil, fmt.Errorf("config file %s: unknown keys %s", path, strings.Join(unknown, ", "))
+    }
+    return settings, nil
+}
+
+func scalar(value interface{}) (string, error) {
+    switch v := value.(type) {
+    case nil:
+        return "", nil
+    case string:
+        return v, nil
+    case []interface{}:
+        items := make([]string, len(v))
+        for i, item := range v {
+            s, err := scalar(item)
+            if err != nil {
+                return "", err
+            }
+            items[i] = s
+        }
+        return strings.Join(items, ","), nil
+    case map[string]interface{}:
+        return "", fmt.Errorf("nested tables are not supported")
+    default:
+        return fmt.Sprint(v), nil
+    }
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+    "io"
+    "strings"
+
+    "gopkg.in/yaml.v3"
+)
+
+const redacted = "<redacted>"
+
+// Print writes the effective configuration as a YAML config file, noting
+// where every setting came from. Secrets that are set are redacted.
+func (c *Config) Print(w io.Writer) error {
+    doc := &yaml.Node{Kind: yaml.MappingNode}
+    for _, f := range c.fields() {
+        value := *f.value
+        if f.secret && value != "" {
+            value = redacted
+        }
+        doc.Content = append(doc.Content,
+            &yaml.Node{Kind: yaml.ScalarNode, Value: strings.ToLower(f.name)},
+            &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, LineComment: c.source(f.name)},
+        )
+    }
+
+    enc := yaml.NewEncoder(w)
+    enc.SetIndent(2)
+    if err := enc.Encode(doc); err != nil {
+        return err
+    }
+    return enc.Close()
+}
+
+func (c *Config) source(name string) string {
+    if source, ok := c.sources[name]; ok {
+        return source
+    }
+    return sourceDefault
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    "strconv"
+    "strings"
+    "time"
+
+    "clicker/internal/clicktoken"
+    "clicker/internal/clientip"
+    "clicker/internal/interfaces/grpc/interceptor"
+)
+
+// Validate checks every setting and the combinations that depend on each
+// other, and reports all problems together.
+func (c *Config) Validate() error {
+    var errs []error
+    for _, f := range c.fields() {
+        if f.validate == nil {
+            continue
+        }
+        if err := f.validate(*f.value); err != nil {
+            errs = append(errs, fmt.Errorf("%s: %w", f.name, err))
+        }
+    }
+
+    if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+        errs = append(errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+    }
+    if (c.GatewayTLSCertFile == "") != (c.GatewayTLSKeyFile == "") {
+        errs = append(errs, errors.New("GATEWAY_TLS_CERT_FILE and GATEWAY_TLS_KEY_FILE must be set together"))
+    }
+    if c.TLSClientCAFile != "" && (c.TLSCertFile == "" || c.GatewayTLSCertFile == "") {
+        errs = append(errs, errors.New("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and GATEWAY_TLS_CERT_FILE"))
+    }
+    if minConns, err := strconv.Atoi(c.PostgresMinConns); err == nil {
+        if maxConns, err := strconv.Atoi(c.PostgresMaxConns); err == nil && minConns > maxConns {
+            errs = append(errs, errors.New("POSTGRES_MIN_CONNS must not exceed POSTGRES_MAX_CONNS"))
+        }
+    }
+    if backoff, err := time.ParseDuration(c.PostgresConnectBackoff); err == nil {
+        if maxBackoff, err := time.ParseDuration(c.PostgresConnectMaxBackoff); err == nil && maxBackoff < backoff {
+            errs = append(errs, errors.New("POSTGRES_CONNECT_MAX_BACKOFF must not be below POSTGRES_CONNECT_BACKOFF"))
+        }
+    }
+    if size, err := strconv.Atoi(c.BatchSize); err == nil {
+        if capacity, err := strconv.Atoi(c.BatchQueueCapacity); err == nil && size > capacity {
+            errs = append(errs, errors.New("BATCH_SIZE must not exceed BATCH_QUEUE_CAPACITY"))
+        }
+    }
+    if c.ClickTokenKeys != "" && c.ClickTokenActiveKey == "" {
+        errs = append(errs, errors.New("CLICK_TOKEN_ACTIVE_KEY is required with CLICK_TOKEN_KEYS"))
+    }
+    if keys, err := clicktoken.ParseKeys(c.ClickTokenKeys); err == nil && c.ClickTokenActiveKey != "" {
+        if _, ok := keys[c.ClickTokenActiveKey]; !ok {
+            errs = append(errs, fmt.Errorf("CLICK_TOKEN_ACTIVE_KEY %q is not one of CLICK_TOKEN_KEYS", c.ClickTokenActiveKey))
+        }
+    }
+    if c.TracingExporter == "file" && c.TracingFile == "" {
+        errs = append(errs, errors.New("TRACING_FILE is required with TRACING_EXPORTER=file"))
+    }
+    return errors.Join(errs...)
+}
+
+func notEmpty(value string) error {
+    if strings.TrimSpace(value) == "" {
+        return errors.New("must not be empty")
+    }
+    return nil
+}
+
+func port(value string) error {
+    n, err := strconv.Atoi(value)
+    if err != nil || n < 1 || n > 65535 {
+        return fmt.Errorf("invalid port %q", value)
+    }
+    return nil
+}
+
+func positiveInt(value string) error {
+    n, err := strconv.Atoi(value)
+    if err != nil || n <= 0 {
+        return fmt.Errorf("%q is not a positive integer", value)
+    }
+    return nil
+}
+
+func nonNegativeInt(value string) error {
+    n, err := strconv.Atoi(value)
+    if err != nil || n < 0 {
+        return fmt.Errorf("%q is not a non-negative integer", value)
+    }
+    return nil
+}
+
+func nonNegativeDuration(value string) error {
+    d, err := time.ParseDuration(value)
+    if err != nil || d < 0 {
+        return fmt.Errorf("%q is not a non-negative duration", value)
+    }
+    return nil
+}
+
+func positiveDuration(value string) error {
+    d, err := time.ParseDuration(value)
+    if err != nil || d <= 0 {
+        return fmt.Errorf("%q is not a positive duration", value)
+    }
+    return nil
+}
+
+func boolean(value string) error {
+    if _, err := strconv.ParseBool(value); err != nil {
+        return fmt.Errorf("%q is not a boolean", value)
+    }
+    return nil
+}
+
+func ratio(value string) error {
+    r, err := strconv.ParseFloat(value, 64)
+    if err != nil || r < 0 || r > 1 {
+        return fmt.Errorf("%q is not a number between 0 and 1", value)
+    }
+    return nil
+}
+
+func oneOf(allowed ...string) func(string) error {
+    return func(value string) error {
+        for _, a := range allowed {
+            if value == a {
+                return nil
+            }
+        }
+        return fmt.Errorf("%q is not one of %s", value, strings.Join(allowed, ", "))
+    }
+}
+
+func proxyList(value string) error {
+    _, err := clientip.ParseTrustedProxies(value)
+    return err
+}
+
+func rateLimits(value string) error {
+    _, err := interceptor.ParseRateLimits(value)
+    return err
+}
+
+func clickTokenKeys(value string) error {
+    _, err := clicktoken.ParseKeys(value)
+    return err
+}
+
+// readableFile accepts an empty path, which leaves the file out.
+func readableFile(value string) error {
+    if value == "" {
+        return nil
+    }
+    f, err := os.Open(value)
+    if err != nil {
+        return err
+    }
+    return f.Close()
+}
+
+// domainList accepts comma separated bare domains, without a scheme, port
+// or path.
+func domainList(value string) error {
+    for _, domain := range strings.Split(value, ",") {
+        domain = strings.TrimSpace(domain)
+        if domain == "" {
+            continue
+        }
+        if strings.ContainsAny(domain, "/:@?#* ") {
+            return fmt.Errorf("%q is not a domain", domain)
+        }
+    }
+    return nil
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+    "errors"
+    "time"
+)
+
+var (
+    ErrAlertRuleNotFound = errors.New("alert rule not found")
+    ErrInvalidAlertRule  = errors.New("invalid alert rule")
+)
+
+// AlertKind selects how an alert rule looks at the clicks of its banner.
+type AlertKind string
+
+const (
+    // AlertThreshold compares the clicks in the window with Threshold.
+    AlertThreshold AlertKind = "threshold"
+    // AlertChange compares the clicks in the window with those of the
+    // window before it; Threshold is the change in percent.
+    AlertChange AlertKind = "change"
+    // AlertNoData fires when the window has no clicks at all.
+    AlertNoData AlertKind = "no_data"
+)
+
+// AlertOperator tells whether threshold and change rules fire above or
+// below their threshold.
+type AlertOperator string
+
+const (
+    AlertAbove AlertOperator = "above"
+    AlertBelow AlertOperator = "below"
+)
+
+type AlertState string
+
+const (
+    AlertOK     AlertState = "ok"
+    AlertFiring AlertState = "firing"
+)
+
+type AlertRule struct {
+    ID         int64         `json:"id"`
+    TenantID   int64         `json:"tenant_id"`
+    BannerID   int64         `json:"banner_id"`
+    Name       string        `json:"name"`
+    Kind       AlertKind     `json:"kind"`
+    Operator   AlertOperator `json:"operator,omitempty"`
+    Threshold  float64       `json:"threshold"`
+    Window     time.Duration `json:"window"`
+    WebhookURL string        `json:"webhook_url"`
+    CreatedAt  time.Time     `json:"created_at"`
+
+    // State is the outcome of the last evaluation and Value the number it
+    // was based on: clicks for threshold and no-data rules, the change in
+    // percent for change rules.
+    State          AlertState `json:"state"`
+    Value          *float64   `json:"value,omitempty"`
+    StateChangedAt *time.Time `json:"state_changed_at,omitempty"`
+    EvaluatedAt    *time.Time `json:"evaluated_at,omitempty"`
+}
+
+// Evaluate decides whether the rule fires for the clicks in the current
+// window and in the window before it. ok is false when the rule cannot be
+// decided, which happens for change rules without clicks to compare with.
+func (r *AlertRule) Evaluate(current, previous int64) (firing bool, value float64, ok bool) {
+    switch r.Kind {
+    case AlertThreshold:
+        value = float64(current)
+        if r.Operator == AlertBelow {
+            return value < r.Threshold, value, true
+        }
+        return value > r.Threshold, value, true
+    case AlertChange:
+        if previous == 0 {
+            return false, 0, false
+        }
+        value = float64(current-previous) / float64(previous) * 100
+        if r.Operator == AlertBelow {
+            return value <= -r.Threshold, value, true
+        }
+        return value >= r.Threshold, value, true
+    case AlertNoData:
+        return current == 0, float64(current), true
+    }
+    return false, 0, false
+}
+
+// AlertNotification is delivered to the webhook of a rule whenever the rule
+// starts firing or is resolved.
+type AlertNotification struct {
+    RuleID    int64         `json:"rule_id"`
+    RuleName  string        `json:"rule_name"`
+    TenantID  int64         `json:"tenant_id"`
+    BannerID  int64         `json:"banner_id"`
+    Kind      AlertKind     `json:"kind"`
+    Operator  AlertOperator `json:"operator,omitempty"`
+    Threshold float64       `json:"threshold"`
+    Window    int64         `json:"window_seconds"`
+    State     AlertState    `json:"state"`
+    Value     float64       `json:"value"`
+    At        time.Time     `json:"at"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+    "errors"
+    "time"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKey is a machine credential owned by a tenant. Only a hash of the key
+// is stored; Prefix is kept so that keys can be told apart in listings.
+// Publishable keys are meant to be embedded in pages for the pixel and
+// redirect endpoints and are accepted nowhere else.
+type APIKey struct {
+    ID          int64      `json:"id"`
+    TenantID    int64      `json:"tenant_id"`
+    Name        string     `json:"name"`
+    Prefix      string     `json:"prefix"`
+    Hash        []byte     `json:"-"`
+    Scopes      []string   `json:"scopes"`
+    Publishable bool       `json:"publishable"`
+    CreatedAt   time.Time  `json:"created_at"`
+    RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
@@ -1,6 +1,83 @@
 package entity
 
+import (
+    "errors"
+    "time"
+)
+
+var (
+    ErrBannerNotFound = errors.New("banner not found")
+    ErrOutOfFlight    = errors.New("banner is out of flight")
+    ErrInvalidFlight  = errors.New("invalid flight schedule")
+    ErrInvalidCaps    = errors.New("invalid click caps")
+)
+
 type Banner struct {
-    ID   int64  `json:"id"`
-    Name string `json:"name"`
+    ID       int64      `json:"id"`
+    TenantID int64      `json:"tenant_id"`
+    Name     string     `json:"name"`
+    StartsAt *time.Time `json:"starts_at,omitempty"`
+    EndsAt   *time.Time `json:"ends_at,omitempty"`
+    Timezone string     `json:"timezone"`
+    Dayparts []Daypart  `json:"dayparts,omitempty"`
+
+    // Click caps; nil means unlimited.
+    DailyClickCap    *int64 `json:"daily_click_cap,omitempty"`
+    LifetimeClickCap *int64 `json:"lifetime_click_cap,omitempty"`
+
+    // TargetURL is the landing page clicks are redirected to. It may contain
+    // the macros listed in redirect.go.
+    TargetURL string `json:"target_url,omitempty"`
+}
+
+// Daypart is a daily window, in minutes since local midnight, during which
+// the banner accepts clicks. An empty Weekdays list means every day.
+type Daypart struct {
+    Weekdays    []time.Weekday `json:"weekdays,omitempty"`
+    StartMinute int            `json:"start_minute"`
+    EndMinute   int            `json:"end_minute"`
+}
+
+// InFlight reports whether t falls within the banner's flight dates and, if
+// any dayparts are configured, within at least one of them. Dayparts are
+// evaluated in loc, which should be the banner's timezone.
+func (b *Banner) InFlight(t time.Time, loc *time.Location) bool {
+    if b.StartsAt != nil && t.Before(*b.StartsAt) {
+        return false
+    }
+    if b.EndsAt != nil && !t.Before(*b.EndsAt) {
+        return false
+    }
+    if len(b.Dayparts) == 0 {
+        return true
+    }
+
+    local := t.In(loc)
+    minute := local.Hour()*60 + local.Minute()
+    for _, daypart := range b.Dayparts {
+        if daypart.covers(local.Weekday(), minute) {
+            return true
+        }
+    }
+    return false
+}
+
+// HasCaps reports whether any click cap is configured for the banner.
+func (b *Banner) HasCaps() bool {
+    return b.DailyClickCap != nil || b.LifetimeClickCap != nil
+}
+
+func (d Daypart) covers(weekday time.Weekday, minute int) bool {
+    if minute < d.StartMinute || minute >= d.EndMinute {
+        return false
+    }
+    if len(d.Weekdays) == 0 {
+        return true
+    }
+    for _, w := range d.Weekdays {
+        if w == weekday {
+            return true
+        }
+    }
+    return false
 }
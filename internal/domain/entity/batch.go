@@ -0,0 +1,26 @@
+package entity
+
+import (
+    "errors"
+    "time"
+)
+
+var (
+    ErrBatcherNotFound      = errors.New("batcher not found")
+    ErrInvalidBatchSettings = errors.New("invalid batch settings")
+)
+
+// BatchSettings describes a batcher that writes ingested items, such as
+// clicks, to the database in batches.
+type BatchSettings struct {
+    Name string
+    // Size is the number of items after which a batch is written.
+    Size int
+    // Timeout is how long collected items wait at most for a full batch.
+    Timeout time.Duration
+    // QueueCapacity is the number of items that can wait to be batched;
+    // it is fixed at startup.
+    QueueCapacity int
+    // QueueDepth is the number of items waiting right now.
+    QueueDepth int
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+    "errors"
+    "time"
+)
+
+var ErrCampaignNotFound = errors.New("campaign not found")
+
+type Campaign struct {
+    ID        int64     `json:"id"`
+    TenantID  int64     `json:"tenant_id"`
+    Name      string    `json:"name"`
+    BannerIDs []int64   `json:"banner_ids"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// BannerClicks is a single banner's share of a campaign-level aggregate.
+type BannerClicks struct {
+    BannerID    int64    `json:"banner_id"`
+    TotalClicks int64    `json:"total_clicks"`
+    Stats       []*Click `json:"stats,omitempty"`
+}
+
+// CampaignStats holds clicks summed across all banners of a campaign
+// together with the per-banner breakdown they were built from.
+type CampaignStats struct {
+    CampaignID  int64           `json:"campaign_id"`
+    TotalClicks  int64           `json:"total_clicks"`
+    UniqueClicks int64           `json:"unique_clicks"`
+    Stats        []*Click        `json:"stats"`
+    Banners      []*BannerClicks `json:"banners"`
+}
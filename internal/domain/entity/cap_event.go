@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type CapKind string
+
+const (
+    CapDaily    CapKind = "daily"
+    CapLifetime CapKind = "lifetime"
+)
+
+// CapEvent is emitted once a banner exhausts one of its click caps, so that
+// ad selection can stop serving it. ResetsAt is set for daily caps only.
+type CapEvent struct {
+    TenantID  int64      `json:"tenant_id"`
+    BannerID  int64      `json:"banner_id"`
+    Kind      CapKind    `json:"kind"`
+    Cap       int64      `json:"cap"`
+    ReachedAt time.Time  `json:"reached_at"`
+    ResetsAt  *time.Time `json:"resets_at,omitempty"`
+}
@@ -1,10 +1,97 @@
 package entity
 
-import "time"
+import (
+    "errors"
+    "time"
+)
+
+var (
+    ErrInvalidClickToken   = errors.New("invalid click token")
+    ErrClickTokenExpired   = errors.New("click token expired")
+    ErrClickTokensDisabled = errors.New("click tokens are not configured")
+    ErrInvalidTimeRange    = errors.New("invalid time range: from is after to")
+)
 
 type Click struct {
-    ID        int64     `json:"id"`
+    ID          int64     `json:"id"`
+    // ClickID is the public identifier of a click, passed to landing pages
+    // and used to attribute conversions.
+    ClickID     string    `json:"click_id,omitempty"`
+    BannerID    int64     `json:"banner_id"`
+    Timestamp   time.Time `json:"timestamp"`
+    Count       int       `json:"count"`
+    OutOfFlight bool      `json:"out_of_flight"`
+    // RejectReason is set for clicks that are recorded but not counted.
+    RejectReason RejectReason `json:"reject_reason,omitempty"`
+    IP           string       `json:"ip,omitempty"`
+    UserAgent    string       `json:"user_agent,omitempty"`
+    // Fingerprint identifies the clicker for unique click estimates.
+    Fingerprint string `json:"-"`
+}
+
+// ClickRequest is a click as reported by a client.
+type ClickRequest struct {
+    BannerID int64
+    // Token is the signed click token from the tracking URL.
+    Token     string
+    // SessionID identifies the clicker when the client knows it; otherwise
+    // clickers are told apart by IP and user agent.
+    SessionID string
+    IP        string
+    UserAgent string
+}
+
+// Fingerprint returns the value unique clickers are counted by.
+func (r *ClickRequest) Fingerprint() string {
+    if r.SessionID != "" {
+        return "s:" + r.SessionID
+    }
+    return "c:" + r.IP + "|" + r.UserAgent
+}
+
+// RejectReason tells why a click was rejected. Rejected clicks are stored
+// apart from real ones and never show up in click counts.
+type RejectReason string
+
+const (
+    RejectMissingToken  RejectReason = "missing_token"
+    RejectInvalidToken  RejectReason = "invalid_token"
+    RejectExpiredToken  RejectReason = "expired_token"
+    RejectReplayedToken RejectReason = "replayed_token"
+    RejectBot           RejectReason = "bot"
+    RejectVelocity      RejectReason = "velocity"
+    RejectDuplicate     RejectReason = "duplicate"
+    RejectDatacenterIP  RejectReason = "datacenter_ip"
+)
+
+// RejectedClicks is the number of clicks rejected for one reason.
+type RejectedClicks struct {
+    Reason RejectReason `json:"reason"`
+    Count  int64        `json:"count"`
+}
+
+// ClickToken is a signed token to be embedded in a tracking URL.
+type ClickToken struct {
+    Value     string    `json:"value"`
     BannerID  int64     `json:"banner_id"`
-    Timestamp time.Time `json:"timestamp"`
-    Count     int       `json:"count"`
+    Placement string    `json:"placement"`
+    ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ClickStatus tells whether a click was counted or why it was not.
+type ClickStatus int
+
+const (
+    ClickAccepted ClickStatus = iota
+    ClickDailyCapReached
+    ClickLifetimeCapReached
+)
+
+// CounterResult describes the outcome of registering a single click.
+type CounterResult struct {
+    TotalClicks int64       `json:"total_clicks"`
+    OutOfFlight bool        `json:"out_of_flight"`
+    Status      ClickStatus `json:"status"`
+    UniqueClicks int64       `json:"unique_clicks"`
+    ClickID      string      `json:"click_id,omitempty"`
 }
@@ -0,0 +1,17 @@
+package entity
+
+import (
+    "time"
+
+    "clicker/internal/hll"
+)
+
+// SketchBucket is the width of the buckets unique clickers are tracked in.
+const SketchBucket = time.Hour
+
+// ClickSketch estimates the unique clickers of a banner within one bucket.
+type ClickSketch struct {
+    BannerID int64
+    Bucket   time.Time
+    Sketch   *hll.Sketch
+}
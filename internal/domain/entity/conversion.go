@@ -0,0 +1,29 @@
+package entity
+
+import (
+    "errors"
+    "time"
+)
+
+var (
+    ErrClickNotFound           = errors.New("click not found")
+    ErrInvalidClick            = errors.New("click was filtered as invalid")
+    ErrInvalidConversion       = errors.New("invalid conversion")
+    ErrConversionOutsideWindow = errors.New("conversion is outside the attribution window")
+    ErrDuplicateConversion     = errors.New("conversion already registered")
+)
+
+// Conversion is a purchase or other goal reached after a click, attributed
+// to the click by its click id.
+type Conversion struct {
+    ID        int64     `json:"id"`
+    ClickID   string    `json:"click_id"`
+    BannerID  int64     `json:"banner_id"`
+    Timestamp time.Time `json:"timestamp"`
+    Value     float64   `json:"value"`
+    // Currency is an ISO 4217 code such as "USD".
+    Currency string `json:"currency"`
+    // TransactionID optionally identifies the conversion at the advertiser
+    // so that repeated postbacks are only counted once.
+    TransactionID string `json:"transaction_id,omitempty"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type Impression struct {
+    ID        int64     `json:"id"`
+    BannerID  int64     `json:"banner_id"`
+    Timestamp time.Time `json:"timestamp"`
+}
+
+// StatsBucket sums clicks, impressions and conversions of a banner over one
+// time bucket. Conversions are counted in the bucket of their click.
+type StatsBucket struct {
+    Timestamp   time.Time `json:"timestamp"`
+    Clicks      int64     `json:"clicks"`
+    Impressions int64     `json:"impressions"`
+    Conversions int64     `json:"conversions"`
+    // Revenue sums the conversion values per currency.
+    Revenue map[string]float64 `json:"revenue,omitempty"`
+}
+
+// CTR returns the click-through rate of the bucket, or 0 without
+// impressions.
+func (b *StatsBucket) CTR() float64 {
+    if b.Impressions == 0 {
+        return 0
+    }
+    return float64(b.Clicks) / float64(b.Impressions)
+}
+
+// ConversionRate returns the share of clicks that converted, or 0 without
+// clicks.
+func (b *StatsBucket) ConversionRate() float64 {
+    if b.Clicks == 0 {
+        return 0
+    }
+    return float64(b.Conversions) / float64(b.Clicks)
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+    "errors"
+    "fmt"
+    "net/url"
+    "strings"
+)
+
+var (
+    ErrNoTargetURL        = errors.New("banner has no target url")
+    ErrInvalidTargetURL   = errors.New("invalid target url")
+    ErrRedirectNotAllowed = errors.New("redirect domain is not allowed")
+)
+
+// Target URL macros, replaced with URL-escaped values on every redirect.
+const (
+    MacroClickID     = "{click_id}"
+    MacroBannerID    = "{banner_id}"
+    MacroUTMSource   = "{utm_source}"
+    MacroUTMMedium   = "{utm_medium}"
+    MacroUTMCampaign = "{utm_campaign}"
+    MacroUTMTerm     = "{utm_term}"
+    MacroUTMContent  = "{utm_content}"
+)
+
+// UTMParams are the query parameters passed through to the UTM macros.
+var UTMParams = []string{"utm_source", "utm_medium", "utm_campaign", "utm_term", "utm_content"}
+
+// DomainAllowlist lists the domains banners may redirect to. A domain also
+// allows its subdomains.
+type DomainAllowlist []string
+
+func (l DomainAllowlist) Allows(host string) bool {
+    host = strings.TrimSuffix(strings.ToLower(host), ".")
+    for _, domain := range l {
+        domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+        if domain == "" {
+            continue
+        }
+        if host == domain || strings.HasSuffix(host, "."+domain) {
+            return true
+        }
+    }
+    return false
+}
+
+// CheckURL verifies that raw is an absolute http(s) URL on an allowed
+// domain.
+func (l DomainAllowlist) CheckURL(raw string) error {
+    u, err := url.Parse(raw)
+    if err != nil {
+        return fmt.Errorf("%w: %v", ErrInvalidTargetURL, err)
+    }
+    if u.Scheme != "http" && u.Scheme != "https" {
+        return fmt.Errorf("%w: scheme must be http or https", ErrInvalidTargetURL)
+    }
+    if u.User != nil {
+        return fmt.Errorf("%w: credentials are not allowed", ErrInvalidTargetURL)
+    }
+    if !l.Allows(u.Hostname()) {
+        return fmt.Errorf("%w: %s", ErrRedirectNotAllowed, u.Hostname())
+    }
+    return nil
+}
+
+// ValidateTargetURL checks a target URL template. Macros may only appear
+// after the host so that substitution can never change where a redirect
+// goes.
+func (l DomainAllowlist) ValidateTargetURL(template string) error {
+    u, err := url.Parse(template)
+    if err != nil {
+        return fmt.Errorf("%w: %v", ErrInvalidTargetURL, err)
+    }
+    if strings.ContainsAny(u.Host, "{}") {
+        return fmt.Errorf("%w: macros are not allowed in the host", ErrInvalidTargetURL)
+    }
+    return l.CheckURL(ExpandTargetURL(template, nil))
+}
+
+// ExpandTargetURL replaces the macros of a target URL template with the
+// URL-escaped values given for them; macros without a value become empty.
+func ExpandTargetURL(template string, values map[string]string) string {
+    macros := []string{MacroClickID, MacroBannerID, MacroUTMSource, MacroUTMMedium, MacroUTMCampaign,
+        MacroUTMTerm, MacroUTMContent}
+    pairs := make([]string, 0, 2*len(macros))
+    for _, macro := range macros {
+        pairs = append(pairs, macro, url.QueryEscape(values[macro]))
+    }
+    return strings.NewReplacer(pairs...).Replace(template)
+}
@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+type Tenant struct {
+    ID        int64     `json:"id"`
+    Name      string    `json:"name"`
+    CreatedAt time.Time `json:"created_at"`
+}
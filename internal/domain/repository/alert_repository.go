@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"clicker/internal/domain/entity"
+)
+
+type AlertRepository interface {
+	// Create stores a rule for a banner of the rule's tenant, or fails with
+	// entity.ErrBannerNotFound.
+	Create(ctx context.Context, rule *entity.AlertRule) error
+	List(ctx context.Context, tenantID int64) ([]*entity.AlertRule, error)
+	Delete(ctx context.Context, tenantID, id int64) error
+	// ListAll returns the rules of every tenant for evaluation.
+	ListAll(ctx context.Context) ([]*entity.AlertRule, error)
+	// SaveState stores the outcome of an evaluation if the rule is still in
+	// the previous state, and reports whether it was. Instances evaluating
+	// the same rule thus agree on which of them saw a state change.
+	SaveState(ctx context.Context, rule *entity.AlertRule, previous entity.AlertState) (bool, error)
+}
+
+// AlertNotifier delivers alert notifications to the webhook of a rule.
+type AlertNotifier interface {
+	// CheckURL returns an error for webhook URLs notifications may not be
+	// sent to.
+	CheckURL(ctx context.Context, url string) error
+	Notify(ctx context.Context, url string, notification *entity.AlertNotification) error
+}
+
+type AlertUseCase interface {
+	CreateRule(ctx context.Context, rule *entity.AlertRule) (*entity.AlertRule, error)
+	ListRules(ctx context.Context) ([]*entity.AlertRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	// Run evaluates every rule once per interval until ctx is done, and
+	// returns once the notifications it sent have finished.
+	Run(ctx context.Context, interval time.Duration)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/auth"
+	"clicker/internal/domain/entity"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	GetActiveByHash(ctx context.Context, hash []byte) (*entity.APIKey, error)
+	List(ctx context.Context, tenantID int64) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, tenantID, id int64) error
+}
+
+type AuthUseCase interface {
+	// Authenticate accepts an API key or a user JWT.
+	Authenticate(ctx context.Context, credential string) (*auth.Identity, error)
+}
+
+type APIKeyUseCase interface {
+	// Issue creates a key and returns it together with its plaintext value,
+	// which is never retrievable again. Publishable keys may only hold
+	// auth.PublishableScopes.
+	Issue(ctx context.Context, name string, scopes []string, publishable bool) (*entity.APIKey, string, error)
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+)
+
+type BannerRepository interface {
+	Get(ctx context.Context, tenantID, id int64) (*entity.Banner, error)
+	UpdateFlight(ctx context.Context, banner *entity.Banner) error
+	UpdateCaps(ctx context.Context, banner *entity.Banner) error
+	UpdateTargetURL(ctx context.Context, banner *entity.Banner) error
+}
+
+type BannerUseCase interface {
+	Get(ctx context.Context, id int64) (*entity.Banner, error)
+	SetFlight(ctx context.Context, banner *entity.Banner) (*entity.Banner, error)
+	SetCaps(ctx context.Context, banner *entity.Banner) (*entity.Banner, error)
+	// SetTargetURL sets the landing page of a banner; an empty URL clears it.
+	SetTargetURL(ctx context.Context, id int64, targetURL string) (*entity.Banner, error)
+	WatchCapEvents(ctx context.Context) (<-chan *entity.CapEvent, error)
+}
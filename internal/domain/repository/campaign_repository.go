@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+)
+
+type CampaignRepository interface {
+	Create(ctx context.Context, campaign *entity.Campaign) error
+	Get(ctx context.Context, tenantID, id int64) (*entity.Campaign, error)
+	List(ctx context.Context, tenantID int64) ([]*entity.Campaign, error)
+	Update(ctx context.Context, campaign *entity.Campaign) error
+	Delete(ctx context.Context, tenantID, id int64) error
+	AddBanners(ctx context.Context, tenantID, campaignID int64, bannerIDs []int64) error
+	RemoveBanners(ctx context.Context, tenantID, campaignID int64, bannerIDs []int64) error
+	GetTotalClicks(ctx context.Context, tenantID, campaignID int64) ([]*entity.BannerClicks, error)
+	GetStats(ctx context.Context, tenantID, campaignID int64, filter StatsFilter) ([]*entity.Click, error)
+}
+
+type CampaignUseCase interface {
+	Create(ctx context.Context, name string, bannerIDs []int64) (*entity.Campaign, error)
+	Get(ctx context.Context, id int64) (*entity.Campaign, error)
+	List(ctx context.Context) ([]*entity.Campaign, error)
+	Update(ctx context.Context, id int64, name string) (*entity.Campaign, error)
+	Delete(ctx context.Context, id int64) error
+	AddBanners(ctx context.Context, campaignID int64, bannerIDs []int64) (*entity.Campaign, error)
+	RemoveBanners(ctx context.Context, campaignID int64, bannerIDs []int64) (*entity.Campaign, error)
+	Counter(ctx context.Context, campaignID int64) (*entity.CampaignStats, error)
+	Stats(ctx context.Context, campaignID int64, filter StatsFilter) (*entity.CampaignStats, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+)
+
+type CapEventPublisher interface {
+	Publish(event *entity.CapEvent)
+}
+
+// CapEventSubscriber hands out a channel of cap events; the returned
+// function must be called to release the subscription.
+type CapEventSubscriber interface {
+	Subscribe() (<-chan *entity.CapEvent, func())
+}
+
+// CapEventBus carries cap events between processes, so that the query
+// nodes streaming them hear about the caps reached on ingest nodes.
+type CapEventBus interface {
+	Notify(ctx context.Context, event *entity.CapEvent) error
+	// Listen calls fn with every event notified until ctx is done or the
+	// connection fails.
+	Listen(ctx context.Context, fn func(*entity.CapEvent)) error
+}
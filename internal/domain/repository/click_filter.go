@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+)
+
+// ClickFilter inspects a click before it is counted.
+type ClickFilter interface {
+	// Check returns why the click is invalid, or "" if it is valid.
+	Check(ctx context.Context, click *entity.Click) entity.RejectReason
+}
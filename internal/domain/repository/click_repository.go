@@ -7,12 +7,30 @@ import (
 )
 
 type ClickRepository interface {
+    // SaveBatch stores clicks, keeping rejected ones apart from real ones.
     SaveBatch(ctx context.Context, clicks []*entity.Click) error
-    GetStats(ctx context.Context, bannerID int64, from, to time.Time) ([]*entity.Click, error)
-    GetTotalClicks(ctx context.Context, bannerID int64) (int64, error)
+    GetStats(ctx context.Context, tenantID, bannerID int64, filter StatsFilter) ([]*entity.Click, error)
+    GetTotalClicks(ctx context.Context, tenantID, bannerID int64) (int64, error)
+    GetClicksSince(ctx context.Context, tenantID, bannerID int64, since time.Time) (int64, error)
+    // GetByClickID returns the click with the given click id, or
+    // entity.ErrClickNotFound. Invalid clicks are returned with their
+    // RejectReason set.
+    GetByClickID(ctx context.Context, tenantID int64, clickID string) (*entity.Click, error)
 }
 
 type ClickUseCase interface {
-    Counter(ctx context.Context, bannerID int64) (int64, error)
-    Stats(ctx context.Context, bannerID int64, from, to time.Time) ([]*entity.Click, error)
+    IngestHealth
+    BatchControl
+    // Counter registers a click. The click token is required once click
+    // tokens are configured.
+    Counter(ctx context.Context, req *entity.ClickRequest) (*entity.CounterResult, error)
+    IssueToken(ctx context.Context, bannerID int64, placement string) (*entity.ClickToken, error)
+    Stats(ctx context.Context, bannerID int64, filter StatsFilter) ([]*entity.Click, error)
+}
+
+type RedirectUseCase interface {
+    // Redirect registers a click on the banner and returns the expanded
+    // target URL the clicker is sent to. utm maps UTM query parameters to
+    // their values.
+    Redirect(ctx context.Context, req *entity.ClickRequest, utm map[string]string) (string, error)
 }
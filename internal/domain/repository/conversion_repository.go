@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+)
+
+type ConversionRepository interface {
+	// Save stores a conversion and sets its ID. A conversion repeating the
+	// transaction id of an earlier one on the banner fails with
+	// entity.ErrDuplicateConversion.
+	Save(ctx context.Context, conversion *entity.Conversion) error
+}
+
+type ConversionUseCase interface {
+	// Register attributes a conversion to the click with its click id.
+	Register(ctx context.Context, conversion *entity.Conversion) (*entity.Conversion, error)
+}
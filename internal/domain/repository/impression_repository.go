@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+)
+
+type ImpressionRepository interface {
+	SaveBatch(ctx context.Context, impressions []*entity.Impression) error
+}
+
+type ImpressionUseCase interface {
+	IngestHealth
+	BatchControl
+	Register(ctx context.Context, bannerID int64) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"clicker/internal/domain/entity"
+)
+
+// IngestHealth reports on the batcher through which a use case writes what
+// it ingests.
+type IngestHealth interface {
+	// Stalled returns an error when queued items are no longer written.
+	Stalled() error
+	// Saturated returns an error when the queue is close to full.
+	Saturated() error
+}
+
+// BatchControl reads, tunes and drains the batcher through which a use case
+// writes what it ingests.
+type BatchControl interface {
+	BatchSettings() *entity.BatchSettings
+	// TuneBatcher changes the batch size and timeout of the batcher.
+	TuneBatcher(size int, timeout time.Duration) *entity.BatchSettings
+	// DrainBatcher writes what is still queued and stops the batcher. It
+	// returns early with an error when ctx is done first. Nothing may be
+	// ingested after it was called.
+	DrainBatcher(ctx context.Context) error
+}
+
+type AdminUseCase interface {
+	ListBatchers(ctx context.Context) ([]*entity.BatchSettings, error)
+	// UpdateBatcher changes the batch size and timeout of the named
+	// batcher; zero values keep the current setting.
+	UpdateBatcher(ctx context.Context, name string, size int, timeout time.Duration) (*entity.BatchSettings, error)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostgresAlertRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresAlertRepository(db *pgxpool.Pool) AlertRepository {
+	return &PostgresAlertRepository{db: db}
+}
+
+const alertRuleColumns = `id, tenant_id, banner_id, name, kind, operator, threshold, window_seconds, webhook_url,
+	state, value, state_changed_at, evaluated_at, created_at`
+
+func (r *PostgresAlertRepository) Create(ctx context.Context, rule *entity.AlertRule) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO alert_rules (tenant_id, banner_id, name, kind, operator, threshold, window_seconds, webhook_url)
+		SELECT b.tenant_id, b.id, $3, $4, $5, $6, $7, $8
+		FROM banners b
+		WHERE b.id = $2 AND b.tenant_id = $1
+		RETURNING id, created_at
+	`, rule.TenantID, rule.BannerID, rule.Name, string(rule.Kind), string(rule.Operator), rule.Threshold,
+		int64(rule.Window.Seconds()), rule.WebhookURL).Scan(&rule.ID, &rule.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.ErrBannerNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert alert rule: %w", err)
+	}
+	rule.State = entity.AlertOK
+	return nil
+}
+
+func (r *PostgresAlertRepository) List(ctx context.Context, tenantID int64) ([]*entity.AlertRule, error) {
+	return r.query(ctx, `
+		SELECT `+alertRuleColumns+`
+		FROM alert_rules
+		WHERE tenant_id = $1
+		ORDER BY id ASC
+	`, tenantID)
+}
+
+func (r *PostgresAlertRepository) ListAll(ctx context.Context) ([]*entity.AlertRule, error) {
+	return r.query(ctx, `
+		SELECT `+alertRuleColumns+`
+		FROM alert_rules
+		ORDER BY id ASC
+	`)
+}
+
+func (r *PostgresAlertRepository) Delete(ctx context.Context, tenantID, id int64) error {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM alert_rules
+		WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrAlertRuleNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAlertRepository) SaveState(ctx context.Context, rule *entity.AlertRule, previous entity.AlertState) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE alert_rules
+		SET state = $2, value = $3, state_changed_at = $4, evaluated_at = $5
+		WHERE id = $1 AND state = $6
+	`, rule.ID, string(rule.State), rule.Value, rule.StateChangedAt, rule.EvaluatedAt, string(previous))
+	if err != nil {
+		return false, fmt.Errorf("failed to save alert state: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PostgresAlertRepository) query(ctx context.Context, sql string, args ...interface{}) ([]*entity.AlertRule, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entity.AlertRule
+	for rows.Next() {
+		var (
+			rule          entity.AlertRule
+			kind          string
+			operator      string
+			state         string
+			windowSeconds int64
+		)
+		if err := rows.Scan(&rule.ID, &rule.TenantID, &rule.BannerID, &rule.Name, &kind, &operator, &rule.Threshold,
+			&windowSeconds, &rule.WebhookURL, &state, &rule.Value, &rule.StateChangedAt, &rule.EvaluatedAt,
+			&rule.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		rule.Kind = entity.AlertKind(kind)
+		rule.Operator = entity.AlertOperator(operator)
+		rule.State = entity.AlertState(state)
+		rule.Window = time.Duration(windowSeconds) * time.Second
+		rules = append(rules, &rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return rules, nil
+}
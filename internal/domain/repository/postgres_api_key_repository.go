@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/auth"
+	"clicker/internal/domain/entity"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostgresAPIKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresAPIKeyRepository(db *pgxpool.Pool) APIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+func (r *PostgresAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO api_keys (tenant_id, name, key_prefix, key_hash, scopes, publishable)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, key.TenantID, key.Name, key.Prefix, key.Hash, key.Scopes, key.Publishable).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAPIKeyRepository) GetActiveByHash(ctx context.Context, hash []byte) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.QueryRow(ctx, `
+		SELECT id, tenant_id, name, key_prefix, scopes, publishable, created_at
+		FROM api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL
+	`, hash).Scan(&key.ID, &key.TenantID, &key.Name, &key.Prefix, &key.Scopes, &key.Publishable, &key.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, auth.ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *PostgresAPIKeyRepository) List(ctx context.Context, tenantID int64) ([]*entity.APIKey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, tenant_id, name, key_prefix, scopes, publishable, created_at, revoked_at
+		FROM api_keys
+		WHERE tenant_id = $1
+		ORDER BY id ASC
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entity.APIKey
+	for rows.Next() {
+		var key entity.APIKey
+		if err := rows.Scan(&key.ID, &key.TenantID, &key.Name, &key.Prefix, &key.Scopes,
+			&key.Publishable, &key.CreatedAt, &key.RevokedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		keys = append(keys, &key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *PostgresAPIKeyRepository) Revoke(ctx context.Context, tenantID, id int64) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND tenant_id = $2 AND revoked_at IS NULL
+	`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrAPIKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostgresBannerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresBannerRepository(db *pgxpool.Pool) BannerRepository {
+	return &PostgresBannerRepository{db: db}
+}
+
+func (r *PostgresBannerRepository) Get(ctx context.Context, tenantID, id int64) (*entity.Banner, error) {
+	var banner entity.Banner
+	err := r.db.QueryRow(ctx, `
+		SELECT id, tenant_id, name, starts_at, ends_at, timezone, daily_click_cap, lifetime_click_cap,
+			COALESCE(target_url, '')
+		FROM banners
+		WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID).Scan(&banner.ID, &banner.TenantID, &banner.Name, &banner.StartsAt, &banner.EndsAt, &banner.Timezone,
+		&banner.DailyClickCap, &banner.LifetimeClickCap, &banner.TargetURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrBannerNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get banner: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT weekdays, start_minute, end_minute
+		FROM banner_dayparts
+		WHERE banner_id = $1
+		ORDER BY id ASC
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dayparts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			weekdays []int16
+			daypart  entity.Daypart
+		)
+		if err := rows.Scan(&weekdays, &daypart.StartMinute, &daypart.EndMinute); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		for _, w := range weekdays {
+			daypart.Weekdays = append(daypart.Weekdays, time.Weekday(w))
+		}
+		banner.Dayparts = append(banner.Dayparts, daypart)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return &banner, nil
+}
+
+func (r *PostgresBannerRepository) UpdateFlight(ctx context.Context, banner *entity.Banner) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE banners SET starts_at = $3, ends_at = $4, timezone = $5
+		WHERE id = $1 AND tenant_id = $2
+	`, banner.ID, banner.TenantID, banner.StartsAt, banner.EndsAt, banner.Timezone)
+	if err != nil {
+		return fmt.Errorf("failed to update banner flight: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrBannerNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM banner_dayparts WHERE banner_id = $1
+	`, banner.ID)
+	if err != nil {
+		return fmt.Errorf("failed to clear dayparts: %w", err)
+	}
+
+	for _, daypart := range banner.Dayparts {
+		weekdays := make([]int16, len(daypart.Weekdays))
+		for i, w := range daypart.Weekdays {
+			weekdays[i] = int16(w)
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO banner_dayparts (banner_id, weekdays, start_minute, end_minute)
+			VALUES ($1, $2, $3, $4)
+		`, banner.ID, weekdays, daypart.StartMinute, daypart.EndMinute)
+		if err != nil {
+			return fmt.Errorf("failed to insert daypart: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresBannerRepository) UpdateCaps(ctx context.Context, banner *entity.Banner) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE banners SET daily_click_cap = $3, lifetime_click_cap = $4
+		WHERE id = $1 AND tenant_id = $2
+	`, banner.ID, banner.TenantID, banner.DailyClickCap, banner.LifetimeClickCap)
+	if err != nil {
+		return fmt.Errorf("failed to update banner caps: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrBannerNotFound
+	}
+	return nil
+}
+
+func (r *PostgresBannerRepository) UpdateTargetURL(ctx context.Context, banner *entity.Banner) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE banners SET target_url = NULLIF($3, '')
+		WHERE id = $1 AND tenant_id = $2
+	`, banner.ID, banner.TenantID, banner.TargetURL)
+	if err != nil {
+		return fmt.Errorf("failed to update banner target url: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrBannerNotFound
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostgresCampaignRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresCampaignRepository(db *pgxpool.Pool) CampaignRepository {
+	return &PostgresCampaignRepository{db: db}
+}
+
+func (r *PostgresCampaignRepository) Create(ctx context.Context, campaign *entity.Campaign) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO campaigns (tenant_id, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`, campaign.TenantID, campaign.Name).Scan(&campaign.ID, &campaign.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert campaign: %w", err)
+	}
+
+	if err := addBanners(ctx, tx, campaign.TenantID, campaign.ID, campaign.BannerIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresCampaignRepository) Get(ctx context.Context, tenantID, id int64) (*entity.Campaign, error) {
+	var campaign entity.Campaign
+	err := r.db.QueryRow(ctx, `
+		SELECT c.id, c.tenant_id, c.name, c.created_at,
+			COALESCE(array_agg(cb.banner_id ORDER BY cb.banner_id)
+				FILTER (WHERE cb.banner_id IS NOT NULL), '{}')
+		FROM campaigns c
+		LEFT JOIN campaign_banners cb ON cb.campaign_id = c.id
+		WHERE c.id = $1 AND c.tenant_id = $2
+		GROUP BY c.id
+	`, id, tenantID).Scan(&campaign.ID, &campaign.TenantID, &campaign.Name, &campaign.CreatedAt, &campaign.BannerIDs)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+	return &campaign, nil
+}
+
+func (r *PostgresCampaignRepository) List(ctx context.Context, tenantID int64) ([]*entity.Campaign, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.tenant_id, c.name, c.created_at,
+			COALESCE(array_agg(cb.banner_id ORDER BY cb.banner_id)
+				FILTER (WHERE cb.banner_id IS NOT NULL), '{}')
+		FROM campaigns c
+		LEFT JOIN campaign_banners cb ON cb.campaign_id = c.id
+		WHERE c.tenant_id = $1
+		GROUP BY c.id
+		ORDER BY c.id ASC
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	var campaigns []*entity.Campaign
+	for rows.Next() {
+		var campaign entity.Campaign
+		if err := rows.Scan(&campaign.ID, &campaign.TenantID, &campaign.Name, &campaign.CreatedAt, &campaign.BannerIDs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		campaigns = append(campaigns, &campaign)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return campaigns, nil
+}
+
+func (r *PostgresCampaignRepository) Update(ctx context.Context, campaign *entity.Campaign) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE campaigns SET name = $3 WHERE id = $1 AND tenant_id = $2
+	`, campaign.ID, campaign.TenantID, campaign.Name)
+	if err != nil {
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrCampaignNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCampaignRepository) Delete(ctx context.Context, tenantID, id int64) error {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM campaigns WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete campaign: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrCampaignNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCampaignRepository) AddBanners(ctx context.Context, tenantID, campaignID int64, bannerIDs []int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockCampaign(ctx, tx, tenantID, campaignID); err != nil {
+		return err
+	}
+
+	if err := addBanners(ctx, tx, tenantID, campaignID, bannerIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresCampaignRepository) RemoveBanners(ctx context.Context, tenantID, campaignID int64, bannerIDs []int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockCampaign(ctx, tx, tenantID, campaignID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM campaign_banners
+		WHERE campaign_id = $1 AND banner_id = ANY($2)
+	`, campaignID, bannerIDs)
+	if err != nil {
+		return fmt.Errorf("failed to remove campaign banners: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresCampaignRepository) GetTotalClicks(ctx context.Context, tenantID, campaignID int64) ([]*entity.BannerClicks, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT cb.banner_id, COUNT(c.id)
+		FROM campaign_banners cb
+		JOIN campaigns cp ON cp.id = cb.campaign_id
+		LEFT JOIN clicks c ON c.banner_id = cb.banner_id AND NOT c.out_of_flight
+		WHERE cb.campaign_id = $1 AND cp.tenant_id = $2
+		GROUP BY cb.banner_id
+		ORDER BY cb.banner_id ASC
+	`, campaignID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total clicks: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []*entity.BannerClicks
+	for rows.Next() {
+		var total entity.BannerClicks
+		if err := rows.Scan(&total.BannerID, &total.TotalClicks); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		totals = append(totals, &total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return totals, nil
+}
+
+func (r *PostgresCampaignRepository) GetStats(ctx context.Context, tenantID, campaignID int64, filter StatsFilter) ([]*entity.Click, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT banner_id, timestamp, count, out_of_flight, reason
+		FROM (
+			SELECT c.banner_id, c.timestamp, c.count, c.out_of_flight, '' AS reason
+			FROM clicks c
+			JOIN campaign_banners cb ON cb.banner_id = c.banner_id
+			JOIN campaigns cp ON cp.id = cb.campaign_id
+			WHERE cb.campaign_id = $1 AND cp.tenant_id = $2
+				AND c.timestamp BETWEEN $3 AND $4
+				AND ($5 OR NOT c.out_of_flight)
+			UNION ALL
+			SELECT i.banner_id, i.timestamp, 1, false, i.reason
+			FROM invalid_clicks i
+			JOIN campaign_banners cb ON cb.banner_id = i.banner_id
+			JOIN campaigns cp ON cp.id = cb.campaign_id
+			WHERE $6 AND cb.campaign_id = $1 AND cp.tenant_id = $2
+				AND i.timestamp BETWEEN $3 AND $4
+		) s
+		ORDER BY timestamp ASC, banner_id ASC
+	`, campaignID, tenantID, filter.From, filter.To, filter.IncludeOutOfFlight, filter.IncludeInvalid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stats: %w", err)
+	}
+	defer rows.Close()
+
+	var clicks []*entity.Click
+	for rows.Next() {
+		var (
+			click  entity.Click
+			reason string
+		)
+		if err := rows.Scan(&click.BannerID, &click.Timestamp, &click.Count, &click.OutOfFlight, &reason); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		click.RejectReason = entity.RejectReason(reason)
+		clicks = append(clicks, &click)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return clicks, nil
+}
+
+func lockCampaign(ctx context.Context, tx pgx.Tx, tenantID, campaignID int64) error {
+	var id int64
+	err := tx.QueryRow(ctx, `
+		SELECT id FROM campaigns WHERE id = $1 AND tenant_id = $2 FOR UPDATE
+	`, campaignID, tenantID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.ErrCampaignNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock campaign: %w", err)
+	}
+	return nil
+}
+
+func addBanners(ctx context.Context, tx pgx.Tx, tenantID, campaignID int64, bannerIDs []int64) error {
+	if len(bannerIDs) == 0 {
+		return nil
+	}
+
+	var found int
+	err := tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM banners WHERE id = ANY($1) AND tenant_id = $2
+	`, bannerIDs, tenantID).Scan(&found)
+	if err != nil {
+		return fmt.Errorf("failed to check banners: %w", err)
+	}
+	if found != len(bannerIDs) {
+		return entity.ErrBannerNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO campaign_banners (campaign_id, banner_id)
+		SELECT $1, unnest($2::integer[])
+		ON CONFLICT DO NOTHING
+	`, campaignID, bannerIDs)
+	if err != nil {
+		return fmt.Errorf("failed to add campaign banners: %w", err)
+	}
+	return nil
+}
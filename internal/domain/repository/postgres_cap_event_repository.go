@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+	"encoding/json"
+	"fmt"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// capEventsChannel is the notification channel cap events travel on.
+const capEventsChannel = "cap_events"
+
+// PostgresCapEventBus carries cap events through LISTEN and NOTIFY. A
+// listener holds one connection of the pool for as long as it listens.
+type PostgresCapEventBus struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresCapEventBus(db *pgxpool.Pool) CapEventBus {
+	return &PostgresCapEventBus{db: db}
+}
+
+func (b *PostgresCapEventBus) Notify(ctx context.Context, event *entity.CapEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode cap event: %w", err)
+	}
+	if _, err := b.db.Exec(ctx, `SELECT pg_notify($1, $2)`, capEventsChannel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify cap event: %w", err)
+	}
+	return nil
+}
+
+func (b *PostgresCapEventBus) Listen(ctx context.Context, fn func(*entity.CapEvent)) error {
+	pooled, err := b.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	// The connection leaves the pool for good: canceling a wait may leave it
+	// unusable, and it must not hand notifications to other callers.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+capEventsChannel); err != nil {
+		return fmt.Errorf("failed to listen for cap events: %w", err)
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to wait for cap events: %w", err)
+		}
+
+		var event entity.CapEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			continue
+		}
+		fn(&event)
+	}
+}
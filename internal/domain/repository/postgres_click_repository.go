@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"clicker/internal/domain/entity"
+	"errors"
 	"fmt"
 	"time"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -24,10 +26,21 @@ func (r *PostgresClickRepository) SaveBatch(ctx context.Context, clicks []*entit
 	defer tx.Rollback(ctx)
 
 	for _, click := range clicks {
+		if click.RejectReason != "" {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO invalid_clicks (banner_id, timestamp, reason, ip, user_agent, click_id)
+				VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''))
+			`, click.BannerID, click.Timestamp, string(click.RejectReason), click.IP, click.UserAgent, click.ClickID)
+			if err != nil {
+				return fmt.Errorf("failed to execute statement: %w", err)
+			}
+			continue
+		}
+
 		_, err := tx.Exec(ctx, `
-			INSERT INTO clicks (banner_id, timestamp)
-			VALUES ($1, $2)
-		`, click.BannerID, click.Timestamp)
+			INSERT INTO clicks (banner_id, timestamp, out_of_flight, click_id)
+			VALUES ($1, $2, $3, NULLIF($4, ''))
+		`, click.BannerID, click.Timestamp, click.OutOfFlight, click.ClickID)
 		if err != nil {
 			return fmt.Errorf("failed to execute statement: %w", err)
 		}
@@ -40,13 +53,25 @@ func (r *PostgresClickRepository) SaveBatch(ctx context.Context, clicks []*entit
 	return nil
 }
 
-func (r *PostgresClickRepository) GetStats(ctx context.Context, bannerID int64, from, to time.Time) ([]*entity.Click, error) {
+func (r *PostgresClickRepository) GetStats(ctx context.Context, tenantID, bannerID int64, filter StatsFilter) ([]*entity.Click, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT banner_id, timestamp
-		FROM clicks
-		WHERE banner_id = $1 AND timestamp BETWEEN $2 AND $3
+		SELECT banner_id, timestamp, out_of_flight, reason
+		FROM (
+			SELECT c.banner_id, c.timestamp, c.out_of_flight, '' AS reason
+			FROM clicks c
+			JOIN banners b ON b.id = c.banner_id
+			WHERE c.banner_id = $1 AND b.tenant_id = $2
+				AND c.timestamp BETWEEN $3 AND $4
+				AND ($5 OR NOT c.out_of_flight)
+			UNION ALL
+			SELECT i.banner_id, i.timestamp, false, i.reason
+			FROM invalid_clicks i
+			JOIN banners b ON b.id = i.banner_id
+			WHERE $6 AND i.banner_id = $1 AND b.tenant_id = $2
+				AND i.timestamp BETWEEN $3 AND $4
+		) s
 		ORDER BY timestamp ASC
-	`, bannerID, from, to)
+	`, bannerID, tenantID, filter.From, filter.To, filter.IncludeOutOfFlight, filter.IncludeInvalid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query stats: %w", err)
 	}
@@ -54,10 +79,14 @@ func (r *PostgresClickRepository) GetStats(ctx context.Context, bannerID int64,
 
 	var clicks []*entity.Click
 	for rows.Next() {
-		var click entity.Click
-		if err := rows.Scan(&click.BannerID, &click.Timestamp); err != nil {
+		var (
+			click  entity.Click
+			reason string
+		)
+		if err := rows.Scan(&click.BannerID, &click.Timestamp, &click.OutOfFlight, &reason); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
+		click.RejectReason = entity.RejectReason(reason)
 		clicks = append(clicks, &click)
 	}
 
@@ -68,13 +97,58 @@ func (r *PostgresClickRepository) GetStats(ctx context.Context, bannerID int64,
 	return clicks, nil
 }
 
-func (r *PostgresClickRepository) GetTotalClicks(ctx context.Context, bannerID int64) (int64, error) {
+func (r *PostgresClickRepository) GetTotalClicks(ctx context.Context, tenantID, bannerID int64) (int64, error) {
 	var totalClicks int64
 	err := r.db.QueryRow(ctx, `
-		SELECT COUNT(*) FROM clicks WHERE banner_id = $1
-	`, bannerID).Scan(&totalClicks)
+		SELECT COUNT(*)
+		FROM clicks c
+		JOIN banners b ON b.id = c.banner_id
+		WHERE c.banner_id = $1 AND b.tenant_id = $2 AND NOT c.out_of_flight
+	`, bannerID, tenantID).Scan(&totalClicks)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total clicks: %w", err)
 	}
 	return totalClicks, nil
 }
+
+func (r *PostgresClickRepository) GetClicksSince(ctx context.Context, tenantID, bannerID int64, since time.Time) (int64, error) {
+	var clicks int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM clicks c
+		JOIN banners b ON b.id = c.banner_id
+		WHERE c.banner_id = $1 AND b.tenant_id = $2
+			AND c.timestamp >= $3 AND NOT c.out_of_flight
+	`, bannerID, tenantID, since).Scan(&clicks)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get clicks since %s: %w", since.Format(time.RFC3339), err)
+	}
+	return clicks, nil
+}
+
+func (r *PostgresClickRepository) GetByClickID(ctx context.Context, tenantID int64, clickID string) (*entity.Click, error) {
+	var (
+		click  entity.Click
+		reason string
+	)
+	err := r.db.QueryRow(ctx, `
+		SELECT c.id, c.click_id, c.banner_id, c.timestamp, c.out_of_flight, ''
+		FROM clicks c
+		JOIN banners b ON b.id = c.banner_id
+		WHERE c.click_id = $1 AND b.tenant_id = $2
+		UNION ALL
+		SELECT i.id, i.click_id, i.banner_id, i.timestamp, false, i.reason
+		FROM invalid_clicks i
+		JOIN banners b ON b.id = i.banner_id
+		WHERE i.click_id = $1 AND b.tenant_id = $2
+		LIMIT 1
+	`, clickID, tenantID).Scan(&click.ID, &click.ClickID, &click.BannerID, &click.Timestamp, &click.OutOfFlight, &reason)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrClickNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get click: %w", err)
+	}
+	click.RejectReason = entity.RejectReason(reason)
+	return &click, nil
+}
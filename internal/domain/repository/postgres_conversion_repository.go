@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostgresConversionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresConversionRepository(db *pgxpool.Pool) ConversionRepository {
+	return &PostgresConversionRepository{db: db}
+}
+
+func (r *PostgresConversionRepository) Save(ctx context.Context, conversion *entity.Conversion) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO conversions (click_id, banner_id, timestamp, value, currency, transaction_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		ON CONFLICT (banner_id, transaction_id) DO NOTHING
+		RETURNING id
+	`, conversion.ClickID, conversion.BannerID, conversion.Timestamp, conversion.Value, conversion.Currency,
+		conversion.TransactionID).Scan(&conversion.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.ErrDuplicateConversion
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save conversion: %w", err)
+	}
+	return nil
+}
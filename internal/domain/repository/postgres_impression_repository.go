@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+	"fmt"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostgresImpressionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresImpressionRepository(db *pgxpool.Pool) ImpressionRepository {
+	return &PostgresImpressionRepository{db: db}
+}
+
+func (r *PostgresImpressionRepository) SaveBatch(ctx context.Context, impressions []*entity.Impression) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, impression := range impressions {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO impressions (banner_id, timestamp)
+			VALUES ($1, $2)
+		`, impression.BannerID, impression.Timestamp)
+		if err != nil {
+			return fmt.Errorf("failed to execute statement: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"clicker/internal/domain/entity"
+	"clicker/internal/hll"
+	"fmt"
+	"sort"
+	"time"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostgresSketchRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresSketchRepository(db *pgxpool.Pool) SketchRepository {
+	return &PostgresSketchRepository{db: db}
+}
+
+func (r *PostgresSketchRepository) Merge(ctx context.Context, sketches []*entity.ClickSketch) error {
+	// Rows are always locked in the same order so that concurrent merges
+	// cannot deadlock.
+	sort.Slice(sketches, func(i, j int) bool {
+		if sketches[i].BannerID != sketches[j].BannerID {
+			return sketches[i].BannerID < sketches[j].BannerID
+		}
+		return sketches[i].Bucket.Before(sketches[j].Bucket)
+	})
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var bannerIDs []int64
+	lifetime := make(map[int64]*hll.Sketch)
+	for _, s := range sketches {
+		// Rows are created empty first so that FOR UPDATE serialises
+		// concurrent merges into the same bucket.
+		_, err := tx.Exec(ctx, `
+			INSERT INTO click_sketches (banner_id, bucket, sketch)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (banner_id, bucket) DO NOTHING
+		`, s.BannerID, s.Bucket, emptySketch())
+		if err != nil {
+			return fmt.Errorf("failed to create sketch: %w", err)
+		}
+		if err := mergeSketch(ctx, tx, `
+			SELECT sketch FROM click_sketches
+			WHERE banner_id = $1 AND bucket = $2
+			FOR UPDATE
+		`, `
+			UPDATE click_sketches SET sketch = $3
+			WHERE banner_id = $1 AND bucket = $2
+		`, s.Sketch, s.BannerID, s.Bucket); err != nil {
+			return err
+		}
+
+		if _, ok := lifetime[s.BannerID]; !ok {
+			lifetime[s.BannerID] = hll.New()
+			bannerIDs = append(bannerIDs, s.BannerID)
+		}
+		lifetime[s.BannerID].Merge(s.Sketch)
+	}
+
+	for _, bannerID := range bannerIDs {
+		sketch := lifetime[bannerID]
+		_, err := tx.Exec(ctx, `
+			INSERT INTO banner_sketches (banner_id, sketch)
+			VALUES ($1, $2)
+			ON CONFLICT (banner_id) DO NOTHING
+		`, bannerID, emptySketch())
+		if err != nil {
+			return fmt.Errorf("failed to create sketch: %w", err)
+		}
+		if err := mergeSketch(ctx, tx, `
+			SELECT sketch FROM banner_sketches
+			WHERE banner_id = $1
+			FOR UPDATE
+		`, `
+			UPDATE banner_sketches SET sketch = $2
+			WHERE banner_id = $1
+		`, sketch, bannerID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresSketchRepository) GetLifetime(ctx context.Context, tenantID int64, bannerIDs []int64) (*hll.Sketch, error) {
+	return r.union(ctx, `
+		SELECT s.sketch
+		FROM banner_sketches s
+		JOIN banners b ON b.id = s.banner_id
+		WHERE s.banner_id = ANY($1) AND b.tenant_id = $2
+	`, bannerIDs, tenantID)
+}
+
+func (r *PostgresSketchRepository) GetRange(ctx context.Context, tenantID int64, bannerIDs []int64, from, to time.Time) (*hll.Sketch, error) {
+	return r.union(ctx, `
+		SELECT s.sketch
+		FROM click_sketches s
+		JOIN banners b ON b.id = s.banner_id
+		WHERE s.banner_id = ANY($1) AND b.tenant_id = $2
+			AND s.bucket >= $3 AND s.bucket <= $4
+	`, bannerIDs, tenantID, from.Truncate(entity.SketchBucket), to)
+}
+
+func (r *PostgresSketchRepository) union(ctx context.Context, query string, args ...interface{}) (*hll.Sketch, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sketches: %w", err)
+	}
+	defer rows.Close()
+
+	union := hll.New()
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		var sketch hll.Sketch
+		if err := sketch.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("failed to decode sketch: %w", err)
+		}
+		union.Merge(&sketch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return union, nil
+}
+
+// mergeSketch locks a stored sketch with selectQuery, merges sketch into it
+// and writes it back with updateQuery, whose last parameter is the sketch.
+func mergeSketch(ctx context.Context, tx pgx.Tx, selectQuery, updateQuery string, sketch *hll.Sketch, keys ...interface{}) error {
+	var data []byte
+	if err := tx.QueryRow(ctx, selectQuery, keys...).Scan(&data); err != nil {
+		return fmt.Errorf("failed to lock sketch: %w", err)
+	}
+
+	var stored hll.Sketch
+	if err := stored.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("failed to decode sketch: %w", err)
+	}
+	stored.Merge(sketch)
+
+	data, err := stored.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode sketch: %w", err)
+	}
+	if _, err := tx.Exec(ctx, updateQuery, append(keys, data)...); err != nil {
+		return fmt.Errorf("failed to update sketch: %w", err)
+	}
+	return nil
+}
+
+func emptySketch() []byte {
+	data, _ := hll.New().MarshalBinary()
+	return data
+}
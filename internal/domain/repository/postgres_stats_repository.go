@@ -16,13 +16,25 @@ func NewPostgresStatsRepository(db *pgxpool.Pool) StatsRepository {
 	return &PostgresStatsRepository{db: db}
 }
 
-func (r *PostgresStatsRepository) GetStats(ctx context.Context, bannerID int64, from, to time.Time) ([]*entity.Click, error) {
+func (r *PostgresStatsRepository) GetStats(ctx context.Context, tenantID, bannerID int64, filter StatsFilter) ([]*entity.Click, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT banner_id, timestamp, count
-		FROM clicks
-		WHERE banner_id = $1 AND timestamp BETWEEN $2 AND $3
+		SELECT banner_id, timestamp, count, out_of_flight, reason
+		FROM (
+			SELECT c.banner_id, c.timestamp, c.count, c.out_of_flight, '' AS reason
+			FROM clicks c
+			JOIN banners b ON b.id = c.banner_id
+			WHERE c.banner_id = $1 AND b.tenant_id = $2
+				AND c.timestamp BETWEEN $3 AND $4
+				AND ($5 OR NOT c.out_of_flight)
+			UNION ALL
+			SELECT i.banner_id, i.timestamp, 1, false, i.reason
+			FROM invalid_clicks i
+			JOIN banners b ON b.id = i.banner_id
+			WHERE $6 AND i.banner_id = $1 AND b.tenant_id = $2
+				AND i.timestamp BETWEEN $3 AND $4
+		) s
 		ORDER BY timestamp ASC
-	`, bannerID, from, to)
+	`, bannerID, tenantID, filter.From, filter.To, filter.IncludeOutOfFlight, filter.IncludeInvalid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query stats: %w", err)
 	}
@@ -30,10 +42,14 @@ func (r *PostgresStatsRepository) GetStats(ctx context.Context, bannerID int64,
 
 	var clicks []*entity.Click
 	for rows.Next() {
-		var click entity.Click
-		if err := rows.Scan(&click.BannerID, &click.Timestamp, &click.Count); err != nil {
+		var (
+			click  entity.Click
+			reason string
+		)
+		if err := rows.Scan(&click.BannerID, &click.Timestamp, &click.Count, &click.OutOfFlight, &reason); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
+		click.RejectReason = entity.RejectReason(reason)
 		clicks = append(clicks, &click)
 	}
 
@@ -43,3 +59,115 @@ func (r *PostgresStatsRepository) GetStats(ctx context.Context, bannerID int64,
 
 	return clicks, nil
 }
+
+func (r *PostgresStatsRepository) GetRejectedClicks(ctx context.Context, tenantID, bannerID int64, filter StatsFilter) ([]*entity.RejectedClicks, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT i.reason, COUNT(*)
+		FROM invalid_clicks i
+		JOIN banners b ON b.id = i.banner_id
+		WHERE i.banner_id = $1 AND b.tenant_id = $2
+			AND i.timestamp BETWEEN $3 AND $4
+		GROUP BY i.reason
+		ORDER BY i.reason ASC
+	`, bannerID, tenantID, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rejected clicks: %w", err)
+	}
+	defer rows.Close()
+
+	var rejected []*entity.RejectedClicks
+	for rows.Next() {
+		var (
+			reason string
+			count  int64
+		)
+		if err := rows.Scan(&reason, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		rejected = append(rejected, &entity.RejectedClicks{Reason: entity.RejectReason(reason), Count: count})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return rejected, nil
+}
+
+func (r *PostgresStatsRepository) GetBuckets(ctx context.Context, tenantID, bannerID int64, filter StatsFilter, bucket time.Duration) ([]*entity.StatsBucket, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH c AS (
+			SELECT to_timestamp((floor(extract(epoch FROM c.timestamp) / $5) * $5)::double precision) AS bucket,
+				SUM(c.count) AS clicks
+			FROM clicks c
+			JOIN banners b ON b.id = c.banner_id
+			WHERE c.banner_id = $1 AND b.tenant_id = $2
+				AND c.timestamp BETWEEN $3 AND $4
+				AND ($6 OR NOT c.out_of_flight)
+			GROUP BY 1
+		), i AS (
+			SELECT to_timestamp((floor(extract(epoch FROM i.timestamp) / $5) * $5)::double precision) AS bucket,
+				SUM(i.count) AS impressions
+			FROM impressions i
+			JOIN banners b ON b.id = i.banner_id
+			WHERE i.banner_id = $1 AND b.tenant_id = $2
+				AND i.timestamp BETWEEN $3 AND $4
+			GROUP BY 1
+		), v AS (
+			SELECT bucket, SUM(conversions)::bigint AS conversions, jsonb_object_agg(currency, revenue) AS revenue
+			FROM (
+				SELECT to_timestamp((floor(extract(epoch FROM c.timestamp) / $5) * $5)::double precision) AS bucket,
+					cv.currency, COUNT(*) AS conversions, SUM(cv.value) AS revenue
+				FROM conversions cv
+				JOIN clicks c ON c.click_id = cv.click_id
+				JOIN banners b ON b.id = c.banner_id
+				WHERE c.banner_id = $1 AND b.tenant_id = $2
+					AND c.timestamp BETWEEN $3 AND $4
+					AND ($6 OR NOT c.out_of_flight)
+				GROUP BY 1, 2
+			) s
+			GROUP BY bucket
+		)
+		SELECT COALESCE(c.bucket, i.bucket), COALESCE(c.clicks, 0), COALESCE(i.impressions, 0),
+			COALESCE(v.conversions, 0), COALESCE(v.revenue, '{}'::jsonb)
+		FROM c
+		FULL OUTER JOIN i ON i.bucket = c.bucket
+		LEFT JOIN v ON v.bucket = c.bucket
+		ORDER BY 1 ASC
+	`, bannerID, tenantID, filter.From, filter.To, int64(bucket.Seconds()), filter.IncludeOutOfFlight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stats buckets: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []*entity.StatsBucket
+	for rows.Next() {
+		var b entity.StatsBucket
+		if err := rows.Scan(&b.Timestamp, &b.Clicks, &b.Impressions, &b.Conversions, &b.Revenue); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		buckets = append(buckets, &b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return buckets, nil
+}
+
+func (r *PostgresStatsRepository) CountClicks(ctx context.Context, tenantID, bannerID int64, from, to time.Time) (int64, error) {
+	var clicks int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(c.count), 0)
+		FROM clicks c
+		JOIN banners b ON b.id = c.banner_id
+		WHERE c.banner_id = $1 AND b.tenant_id = $2
+			AND c.timestamp >= $3 AND c.timestamp < $4
+			AND NOT c.out_of_flight
+	`, bannerID, tenantID, from, to).Scan(&clicks)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count clicks: %w", err)
+	}
+	return clicks, nil
+}
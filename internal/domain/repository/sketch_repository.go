@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"clicker/internal/domain/entity"
+	"clicker/internal/hll"
+)
+
+type SketchRepository interface {
+	// Merge folds sketches into the stored bucket sketches and into the
+	// lifetime sketches of their banners.
+	Merge(ctx context.Context, sketches []*entity.ClickSketch) error
+	// GetLifetime returns the union of the lifetime sketches of the banners.
+	GetLifetime(ctx context.Context, tenantID int64, bannerIDs []int64) (*hll.Sketch, error)
+	// GetRange returns the union of the banners' bucket sketches for every
+	// bucket overlapping [from, to].
+	GetRange(ctx context.Context, tenantID int64, bannerIDs []int64, from, to time.Time) (*hll.Sketch, error)
+}
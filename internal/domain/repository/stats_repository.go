@@ -6,12 +6,32 @@ import (
 	"clicker/internal/domain/entity"
 )
 
+// StatsFilter narrows a stats query. Out-of-flight clicks are excluded
+// unless IncludeOutOfFlight is set, and invalid clicks unless
+// IncludeInvalid is set.
+type StatsFilter struct {
+	From               time.Time
+	To                 time.Time
+	IncludeOutOfFlight bool
+	IncludeInvalid     bool
+}
+
 type StatsRepository interface {
-	GetStats(ctx context.Context, bannerID int64, from, to time.Time) ([]*entity.Click, error)
+	GetStats(ctx context.Context, tenantID, bannerID int64, filter StatsFilter) ([]*entity.Click, error)
+	GetRejectedClicks(ctx context.Context, tenantID, bannerID int64, filter StatsFilter) ([]*entity.RejectedClicks, error)
+	// GetBuckets sums valid clicks, impressions and the conversions of those
+	// clicks into buckets of the given width, aligned to the unix epoch.
+	// Buckets without clicks or impressions are omitted.
+	GetBuckets(ctx context.Context, tenantID, bannerID int64, filter StatsFilter, bucket time.Duration) ([]*entity.StatsBucket, error)
+	// CountClicks counts the valid in-flight clicks in [from, to).
+	CountClicks(ctx context.Context, tenantID, bannerID int64, from, to time.Time) (int64, error)
 }
 
 type StatsUseCase interface {
-	GetStats(ctx context.Context, bannerID int64, from, to time.Time) ([]*entity.Click, error)
+	GetStats(ctx context.Context, bannerID int64, filter StatsFilter) ([]*entity.Click, error)
+	GetRejectedClicks(ctx context.Context, bannerID int64, filter StatsFilter) ([]*entity.RejectedClicks, error)
+	// GetUniqueClicks estimates the unique clickers of the banner in the
+	// hourly buckets overlapping the filter's range.
+	GetUniqueClicks(ctx context.Context, bannerID int64, filter StatsFilter) (int64, error)
+	GetBuckets(ctx context.Context, bannerID int64, filter StatsFilter, bucket time.Duration) ([]*entity.StatsBucket, error)
 }
-
-
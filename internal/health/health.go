@@ -0,0 +1,203 @@
+// Package health checks the dependencies of the service and reports the
+// results to HTTP probes and to the standard gRPC health service.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// checkTimeout bounds a single run of a check.
+const checkTimeout = 2 * time.Second
+
+var errNotChecked = errors.New("not checked yet")
+
+// Func checks a dependency and returns why it is unhealthy, or nil.
+type Func func(ctx context.Context) error
+
+type check struct {
+	name     string
+	fn       Func
+	liveness bool
+}
+
+// Checker runs its checks periodically and keeps their latest results.
+// Liveness checks fail when only a restart helps, such as a stuck batcher;
+// readiness checks fail when the service should not get traffic for now,
+// such as while the database is down. /livez reports the liveness checks,
+// /readyz and the gRPC health service all of them.
+//
+// Checks and services are added before Run is called.
+type Checker struct {
+	grpc     *grpchealth.Server
+	logger   *slog.Logger
+	checks   []check
+	services map[string][]string
+
+	mu       sync.RWMutex
+	results  map[string]error
+	shutdown bool
+}
+
+// NewChecker returns a checker that reports to server. Every service is
+// reported as not serving until the checks it depends on have passed.
+func NewChecker(server *grpchealth.Server, logger *slog.Logger) *Checker {
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return &Checker{
+		grpc:     server,
+		logger:   logger,
+		services: make(map[string][]string),
+		results:  make(map[string]error),
+	}
+}
+
+// Liveness adds a check that fails both probes.
+func (c *Checker) Liveness(name string, fn Func) {
+	c.checks = append(c.checks, check{name: name, fn: fn, liveness: true})
+}
+
+// Readiness adds a check that only fails the readiness probe.
+func (c *Checker) Readiness(name string, fn Func) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Service makes the gRPC health status of service depend on the named
+// checks. The overall status, service "", depends on every check.
+func (c *Checker) Service(service string, checks ...string) {
+	c.services[service] = append([]string{}, checks...)
+	c.grpc.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Run checks every interval until ctx is done.
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.checkAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports the service as not ready from now on, so that load
+// balancers stop sending traffic while it drains.
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	c.shutdown = true
+	c.mu.Unlock()
+	c.grpc.Shutdown()
+}
+
+func (c *Checker) checkAll(ctx context.Context) {
+	results := make(map[string]error, len(c.checks))
+	for _, ch := range c.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		results[ch.name] = ch.fn(checkCtx)
+		cancel()
+	}
+
+	c.mu.Lock()
+	for name, err := range results {
+		previous, checked := c.results[name]
+		switch {
+		case err != nil && (!checked || previous == nil):
+			c.logger.WarnContext(ctx, "Health check failed", "check", name, "error", err)
+		case err == nil && checked && previous != nil:
+			c.logger.InfoContext(ctx, "Health check recovered", "check", name)
+		}
+	}
+	c.results = results
+	c.mu.Unlock()
+
+	c.grpc.SetServingStatus("", servingStatus(c.failing(nil, false) == nil))
+	for service, checks := range c.services {
+		c.grpc.SetServingStatus(service, servingStatus(c.failing(checks, false) == nil))
+	}
+}
+
+// failing returns the errors of the failed checks among names, or of all
+// checks when names is nil. With livenessOnly set readiness checks are
+// skipped, as are checks that have not run yet.
+func (c *Checker) failing(names []string, livenessOnly bool) map[string]error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var failed map[string]error
+	fail := func(name string, err error) {
+		if failed == nil {
+			failed = make(map[string]error)
+		}
+		failed[name] = err
+	}
+
+	if c.shutdown && !livenessOnly {
+		fail("shutdown", errors.New("shutting down"))
+	}
+	for _, ch := range c.checks {
+		if livenessOnly && !ch.liveness || names != nil && !slices.Contains(names, ch.name) {
+			continue
+		}
+		err, checked := c.results[ch.name]
+		switch {
+		case !checked && !livenessOnly:
+			fail(ch.name, errNotChecked)
+		case err != nil:
+			fail(ch.name, err)
+		}
+	}
+	return failed
+}
+
+// Register adds the /livez and /readyz probes to router, along with /health
+// as a deprecated alias of /readyz for probes set up before they existed.
+func (c *Checker) Register(router *mux.Router) {
+	router.HandleFunc("/livez", c.probe(true)).Methods(http.MethodGet)
+	router.HandleFunc("/readyz", c.probe(false)).Methods(http.MethodGet)
+	router.HandleFunc("/health", c.probe(false)).Methods(http.MethodGet)
+}
+
+type probeResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func (c *Checker) probe(livenessOnly bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := probeResponse{Status: "ok"}
+		code := http.StatusOK
+		if failed := c.failing(nil, livenessOnly); len(failed) > 0 {
+			response.Status = "failing"
+			response.Checks = make(map[string]string, len(failed))
+			for name, err := range failed {
+				response.Checks[name] = err.Error()
+			}
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func servingStatus(ok bool) healthpb.HealthCheckResponse_ServingStatus {
+	if ok {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
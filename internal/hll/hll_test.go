@@ -0,0 +1,109 @@
+package hll
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func fill(s *Sketch, from, to int) {
+	for i := from; i < to; i++ {
+		s.AddString("visitor-" + strconv.Itoa(i))
+	}
+}
+
+// within reports whether the estimate is within three standard errors
+// (1.04/sqrt(m), about 1.6%) of the true count.
+func within(estimate int64, n int) bool {
+	tolerance := 3 * 1.04 / math.Sqrt(registers) * float64(n)
+	return math.Abs(float64(estimate)-float64(n)) <= math.Max(tolerance, 1)
+}
+
+func TestEstimate(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 100, 1000, 10000, 100000, 1000000} {
+		s := New()
+		fill(s, 0, n)
+		if got := s.Estimate(); !within(got, n) {
+			t.Errorf("Estimate of %d values = %d", n, got)
+		}
+	}
+}
+
+func TestEstimateIgnoresRepeats(t *testing.T) {
+	s := New()
+	for round := 0; round < 5; round++ {
+		fill(s, 0, 1000)
+	}
+	if got := s.Estimate(); !within(got, 1000) {
+		t.Errorf("Estimate of 1000 values added 5 times = %d", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a, b, union := New(), New(), New()
+	fill(a, 0, 30000)
+	fill(b, 20000, 50000)
+	fill(union, 0, 50000)
+
+	a.Merge(b)
+	if !bytes.Equal(a.registers, union.registers) {
+		t.Error("merged sketch differs from the sketch of the union")
+	}
+	if got := a.Estimate(); !within(got, 50000) {
+		t.Errorf("Estimate of merged sketch = %d, want about 50000", got)
+	}
+
+	empty := New()
+	empty.Merge(union)
+	if !bytes.Equal(empty.registers, union.registers) {
+		t.Error("merging into an empty sketch does not copy it")
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	s := New()
+	fill(s, 0, 5000)
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if len(data) != 1+registers || data[0] != version {
+		t.Fatalf("MarshalBinary = %d bytes, version %d", len(data), data[0])
+	}
+
+	var decoded Sketch
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if decoded.Estimate() != s.Estimate() || !bytes.Equal(decoded.registers, s.registers) {
+		t.Error("decoded sketch differs from the original")
+	}
+
+	data[1]++
+	if decoded.registers[0] == data[1] {
+		t.Error("decoded sketch shares memory with the encoded data")
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	valid, err := New().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongVersion := append([]byte{version + 1}, valid[1:]...)
+
+	for name, data := range map[string][]byte{
+		"empty":         nil,
+		"short":         valid[:len(valid)-1],
+		"long":          append(append([]byte{}, valid...), 0),
+		"wrong version": wrongVersion,
+	} {
+		var s Sketch
+		if err := s.UnmarshalBinary(data); !errors.Is(err, ErrInvalidSketch) {
+			t.Errorf("%s: UnmarshalBinary = %v, want ErrInvalidSketch", name, err)
+		}
+	}
+}
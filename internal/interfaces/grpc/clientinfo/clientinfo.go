@@ -0,0 +1,42 @@
+// Package clientinfo extracts details about the client behind a gRPC call,
+// looking through the REST gateway for calls it relays.
+package clientinfo
+
+import (
+	"context"
+
+	"clicker/internal/clientip"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// IP returns the address of the caller. Calls relayed by the gateway arrive
+// from loopback with the address the gateway received the request from
+// appended to "x-forwarded-for", which clientip resolves through the
+// trusted proxies.
+func IP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return clientip.Resolve(p.Addr.String(), md.Get("x-forwarded-for"))
+}
+
+// UserAgent returns the user agent of the caller, preferring the one of the
+// HTTP client over that of the gateway's own gRPC client.
+func UserAgent(ctx context.Context) string {
+	if ua := first(ctx, "grpcgateway-user-agent"); ua != "" {
+		return ua
+	}
+	return first(ctx, "user-agent")
+}
+
+func first(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
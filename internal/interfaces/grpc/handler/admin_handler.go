@@ -0,0 +1,54 @@
+package handler
+
+import (
+    "context"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+    "clicker/pkg/admin"
+)
+
+type AdminHandler struct {
+    admin.UnimplementedAdminServiceServer
+    useCase repository.AdminUseCase
+}
+
+func NewAdminHandler(useCase repository.AdminUseCase) *AdminHandler {
+    return &AdminHandler{
+        useCase: useCase,
+    }
+}
+
+func (h *AdminHandler) ListBatchers(ctx context.Context, req *admin.ListBatchersRequest) (*admin.ListBatchersResponse, error) {
+    batchers, err := h.useCase.ListBatchers(ctx)
+    if err != nil {
+        return nil, statusError(err)
+    }
+
+    response := &admin.ListBatchersResponse{
+        Batchers: make([]*admin.Batcher, len(batchers)),
+    }
+    for i, settings := range batchers {
+        response.Batchers[i] = toBatcherProto(settings)
+    }
+    return response, nil
+}
+
+func (h *AdminHandler) UpdateBatcher(ctx context.Context, req *admin.UpdateBatcherRequest) (*admin.Batcher, error) {
+    settings, err := h.useCase.UpdateBatcher(ctx, req.Name, int(req.Size), time.Duration(req.TimeoutMs)*time.Millisecond)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toBatcherProto(settings), nil
+}
+
+func toBatcherProto(settings *entity.BatchSettings) *admin.Batcher {
+    return &admin.Batcher{
+        Name:          settings.Name,
+        Size:          int32(settings.Size),
+        TimeoutMs:     settings.Timeout.Milliseconds(),
+        QueueCapacity: int32(settings.QueueCapacity),
+        QueueDepth:    int32(settings.QueueDepth),
+    }
+}
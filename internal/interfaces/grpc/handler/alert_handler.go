@@ -0,0 +1,80 @@
+package handler
+
+import (
+    "context"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+    "clicker/pkg/alert"
+)
+
+type AlertHandler struct {
+    alert.UnimplementedAlertServiceServer
+    useCase repository.AlertUseCase
+}
+
+func NewAlertHandler(useCase repository.AlertUseCase) *AlertHandler {
+    return &AlertHandler{
+        useCase: useCase,
+    }
+}
+
+func (h *AlertHandler) CreateAlertRule(ctx context.Context, req *alert.CreateAlertRuleRequest) (*alert.AlertRule, error) {
+    rule, err := h.useCase.CreateRule(ctx, &entity.AlertRule{
+        BannerID:   req.BannerId,
+        Name:       req.Name,
+        Kind:       entity.AlertKind(req.Kind),
+        Operator:   entity.AlertOperator(req.Operator),
+        Threshold:  req.Threshold,
+        Window:     time.Duration(req.WindowSeconds) * time.Second,
+        WebhookURL: req.WebhookUrl,
+    })
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toAlertRuleProto(rule), nil
+}
+
+func (h *AlertHandler) ListAlertRules(ctx context.Context, req *alert.ListAlertRulesRequest) (*alert.ListAlertRulesResponse, error) {
+    rules, err := h.useCase.ListRules(ctx)
+    if err != nil {
+        return nil, statusError(err)
+    }
+
+    response := &alert.ListAlertRulesResponse{
+        Rules: make([]*alert.AlertRule, len(rules)),
+    }
+    for i, rule := range rules {
+        response.Rules[i] = toAlertRuleProto(rule)
+    }
+    return response, nil
+}
+
+func (h *AlertHandler) DeleteAlertRule(ctx context.Context, req *alert.DeleteAlertRuleRequest) (*alert.DeleteAlertRuleResponse, error) {
+    if err := h.useCase.DeleteRule(ctx, req.RuleId); err != nil {
+        return nil, statusError(err)
+    }
+    return &alert.DeleteAlertRuleResponse{}, nil
+}
+
+func toAlertRuleProto(rule *entity.AlertRule) *alert.AlertRule {
+    r := &alert.AlertRule{
+        Id:             rule.ID,
+        BannerId:       rule.BannerID,
+        Name:           rule.Name,
+        Kind:           string(rule.Kind),
+        Operator:       string(rule.Operator),
+        Threshold:      rule.Threshold,
+        WindowSeconds:  int64(rule.Window.Seconds()),
+        WebhookUrl:     rule.WebhookURL,
+        State:          string(rule.State),
+        StateChangedAt: toUnix(rule.StateChangedAt),
+        EvaluatedAt:    toUnix(rule.EvaluatedAt),
+        CreatedAt:      rule.CreatedAt.Unix(),
+    }
+    if rule.Value != nil {
+        r.Value = *rule.Value
+    }
+    return r
+}
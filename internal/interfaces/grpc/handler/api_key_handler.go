@@ -0,0 +1,75 @@
+package handler
+
+import (
+    "context"
+    "strings"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+    "clicker/pkg/apikey"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+type APIKeyHandler struct {
+    apikey.UnimplementedApiKeyServiceServer
+    useCase repository.APIKeyUseCase
+}
+
+func NewAPIKeyHandler(useCase repository.APIKeyUseCase) *APIKeyHandler {
+    return &APIKeyHandler{
+        useCase: useCase,
+    }
+}
+
+func (h *APIKeyHandler) IssueApiKey(ctx context.Context, req *apikey.IssueApiKeyRequest) (*apikey.IssueApiKeyResponse, error) {
+    if strings.TrimSpace(req.Name) == "" {
+        return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+    }
+    if len(req.Scopes) == 0 {
+        return nil, status.Error(codes.InvalidArgument, "scopes must not be empty")
+    }
+
+    key, plaintext, err := h.useCase.Issue(ctx, req.Name, req.Scopes, req.Publishable)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return &apikey.IssueApiKeyResponse{
+        Key:    toAPIKeyProto(key),
+        ApiKey: plaintext,
+    }, nil
+}
+
+func (h *APIKeyHandler) ListApiKeys(ctx context.Context, req *apikey.ListApiKeysRequest) (*apikey.ListApiKeysResponse, error) {
+    keys, err := h.useCase.List(ctx)
+    if err != nil {
+        return nil, statusError(err)
+    }
+
+    response := &apikey.ListApiKeysResponse{
+        Keys: make([]*apikey.ApiKey, len(keys)),
+    }
+    for i, key := range keys {
+        response.Keys[i] = toAPIKeyProto(key)
+    }
+    return response, nil
+}
+
+func (h *APIKeyHandler) RevokeApiKey(ctx context.Context, req *apikey.RevokeApiKeyRequest) (*apikey.RevokeApiKeyResponse, error) {
+    if err := h.useCase.Revoke(ctx, req.KeyId); err != nil {
+        return nil, statusError(err)
+    }
+    return &apikey.RevokeApiKeyResponse{}, nil
+}
+
+func toAPIKeyProto(key *entity.APIKey) *apikey.ApiKey {
+    return &apikey.ApiKey{
+        Id:          key.ID,
+        Name:        key.Name,
+        Prefix:      key.Prefix,
+        Scopes:      key.Scopes,
+        Publishable: key.Publishable,
+        CreatedAt:   key.CreatedAt.Unix(),
+        RevokedAt:   toUnix(key.RevokedAt),
+    }
+}
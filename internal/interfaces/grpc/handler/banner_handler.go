@@ -0,0 +1,172 @@
+package handler
+
+import (
+    "context"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+    "clicker/pkg/banner"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+type BannerHandler struct {
+    banner.UnimplementedBannerServiceServer
+    useCase repository.BannerUseCase
+}
+
+func NewBannerHandler(useCase repository.BannerUseCase) *BannerHandler {
+    return &BannerHandler{
+        useCase: useCase,
+    }
+}
+
+func (h *BannerHandler) GetBanner(ctx context.Context, req *banner.GetBannerRequest) (*banner.Banner, error) {
+    b, err := h.useCase.Get(ctx, req.BannerId)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toBannerProto(b), nil
+}
+
+func (h *BannerHandler) SetBannerFlight(ctx context.Context, req *banner.SetBannerFlightRequest) (*banner.Banner, error) {
+    b := &entity.Banner{ID: req.BannerId}
+    if flight := req.Flight; flight != nil {
+        b.StartsAt = fromUnix(flight.StartsAt)
+        b.EndsAt = fromUnix(flight.EndsAt)
+        b.Timezone = flight.Timezone
+        for _, dp := range flight.Dayparts {
+            daypart := entity.Daypart{
+                StartMinute: int(dp.StartMinute),
+                EndMinute:   int(dp.EndMinute),
+            }
+            for _, w := range dp.Weekdays {
+                daypart.Weekdays = append(daypart.Weekdays, time.Weekday(w))
+            }
+            b.Dayparts = append(b.Dayparts, daypart)
+        }
+    }
+
+    b, err := h.useCase.SetFlight(ctx, b)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toBannerProto(b), nil
+}
+
+func (h *BannerHandler) SetBannerCaps(ctx context.Context, req *banner.SetBannerCapsRequest) (*banner.Banner, error) {
+    b := &entity.Banner{ID: req.BannerId}
+    if caps := req.Caps; caps != nil {
+        if caps.DailyClicks < 0 || caps.LifetimeClicks < 0 {
+            return nil, status.Error(codes.InvalidArgument, "caps must not be negative")
+        }
+        b.DailyClickCap = fromCap(caps.DailyClicks)
+        b.LifetimeClickCap = fromCap(caps.LifetimeClicks)
+    }
+
+    b, err := h.useCase.SetCaps(ctx, b)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toBannerProto(b), nil
+}
+
+func (h *BannerHandler) SetBannerTargetURL(ctx context.Context, req *banner.SetBannerTargetURLRequest) (*banner.Banner, error) {
+    b, err := h.useCase.SetTargetURL(ctx, req.BannerId, req.TargetUrl)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toBannerProto(b), nil
+}
+
+func (h *BannerHandler) WatchCapEvents(req *banner.WatchCapEventsRequest, stream banner.BannerService_WatchCapEventsServer) error {
+    events, err := h.useCase.WatchCapEvents(stream.Context())
+    if err != nil {
+        return statusError(err)
+    }
+
+    for event := range events {
+        if err := stream.Send(toCapEventProto(event)); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func toCapEventProto(event *entity.CapEvent) *banner.CapEvent {
+    kind := banner.CapEvent_KIND_UNSPECIFIED
+    switch event.Kind {
+    case entity.CapDaily:
+        kind = banner.CapEvent_KIND_DAILY
+    case entity.CapLifetime:
+        kind = banner.CapEvent_KIND_LIFETIME
+    }
+
+    return &banner.CapEvent{
+        BannerId:  event.BannerID,
+        Kind:      kind,
+        Cap:       event.Cap,
+        ReachedAt: event.ReachedAt.Unix(),
+        ResetsAt:  toUnix(event.ResetsAt),
+    }
+}
+
+func toBannerProto(b *entity.Banner) *banner.Banner {
+    flight := &banner.Flight{
+        StartsAt: toUnix(b.StartsAt),
+        EndsAt:   toUnix(b.EndsAt),
+        Timezone: b.Timezone,
+        Dayparts: make([]*banner.Flight_Daypart, len(b.Dayparts)),
+    }
+    for i, daypart := range b.Dayparts {
+        dp := &banner.Flight_Daypart{
+            StartMinute: int32(daypart.StartMinute),
+            EndMinute:   int32(daypart.EndMinute),
+        }
+        for _, w := range daypart.Weekdays {
+            dp.Weekdays = append(dp.Weekdays, int32(w))
+        }
+        flight.Dayparts[i] = dp
+    }
+
+    return &banner.Banner{
+        Id:     b.ID,
+        Name:   b.Name,
+        Flight: flight,
+        Caps: &banner.Caps{
+            DailyClicks:    toCap(b.DailyClickCap),
+            LifetimeClicks: toCap(b.LifetimeClickCap),
+        },
+        TargetUrl: b.TargetURL,
+    }
+}
+
+func fromUnix(ts int64) *time.Time {
+    if ts == 0 {
+        return nil
+    }
+    t := time.Unix(ts, 0)
+    return &t
+}
+
+func toUnix(t *time.Time) int64 {
+    if t == nil {
+        return 0
+    }
+    return t.Unix()
+}
+
+func fromCap(value int64) *int64 {
+    if value == 0 {
+        return nil
+    }
+    return &value
+}
+
+func toCap(value *int64) int64 {
+    if value == nil {
+        return 0
+    }
+    return *value
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+    "context"
+    "strings"
+    "time"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+    "clicker/pkg/campaign"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+type CampaignHandler struct {
+    campaign.UnimplementedCampaignServiceServer
+    useCase repository.CampaignUseCase
+}
+
+func NewCampaignHandler(useCase repository.CampaignUseCase) *CampaignHandler {
+    return &CampaignHandler{
+        useCase: useCase,
+    }
+}
+
+func (h *CampaignHandler) CreateCampaign(ctx context.Context, req *campaign.CreateCampaignRequest) (*campaign.Campaign, error) {
+    if strings.TrimSpace(req.Name) == "" {
+        return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+    }
+
+    c, err := h.useCase.Create(ctx, req.Name, req.BannerIds)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toCampaignProto(c), nil
+}
+
+func (h *CampaignHandler) GetCampaign(ctx context.Context, req *campaign.GetCampaignRequest) (*campaign.Campaign, error) {
+    c, err := h.useCase.Get(ctx, req.CampaignId)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toCampaignProto(c), nil
+}
+
+func (h *CampaignHandler) ListCampaigns(ctx context.Context, req *campaign.ListCampaignsRequest) (*campaign.ListCampaignsResponse, error) {
+    campaigns, err := h.useCase.List(ctx)
+    if err != nil {
+        return nil, statusError(err)
+    }
+
+    response := &campaign.ListCampaignsResponse{
+        Campaigns: make([]*campaign.Campaign, len(campaigns)),
+    }
+    for i, c := range campaigns {
+        response.Campaigns[i] = toCampaignProto(c)
+    }
+    return response, nil
+}
+
+func (h *CampaignHandler) UpdateCampaign(ctx context.Context, req *campaign.UpdateCampaignRequest) (*campaign.Campaign, error) {
+    if strings.TrimSpace(req.Name) == "" {
+        return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+    }
+
+    c, err := h.useCase.Update(ctx, req.CampaignId, req.Name)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toCampaignProto(c), nil
+}
+
+func (h *CampaignHandler) DeleteCampaign(ctx context.Context, req *campaign.DeleteCampaignRequest) (*campaign.DeleteCampaignResponse, error) {
+    if err := h.useCase.Delete(ctx, req.CampaignId); err != nil {
+        return nil, statusError(err)
+    }
+    return &campaign.DeleteCampaignResponse{}, nil
+}
+
+func (h *CampaignHandler) AddCampaignBanners(ctx context.Context, req *campaign.CampaignBannersRequest) (*campaign.Campaign, error) {
+    if len(req.BannerIds) == 0 {
+        return nil, status.Error(codes.InvalidArgument, "banner_ids must not be empty")
+    }
+
+    c, err := h.useCase.AddBanners(ctx, req.CampaignId, req.BannerIds)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toCampaignProto(c), nil
+}
+
+func (h *CampaignHandler) RemoveCampaignBanners(ctx context.Context, req *campaign.CampaignBannersRequest) (*campaign.Campaign, error) {
+    if len(req.BannerIds) == 0 {
+        return nil, status.Error(codes.InvalidArgument, "banner_ids must not be empty")
+    }
+
+    c, err := h.useCase.RemoveBanners(ctx, req.CampaignId, req.BannerIds)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return toCampaignProto(c), nil
+}
+
+func (h *CampaignHandler) CampaignCounter(ctx context.Context, req *campaign.CampaignCounterRequest) (*campaign.CampaignCounterResponse, error) {
+    result, err := h.useCase.Counter(ctx, req.CampaignId)
+    if err != nil {
+        return nil, statusError(err)
+    }
+
+    response := &campaign.CampaignCounterResponse{
+        TotalClicks:  result.TotalClicks,
+        UniqueClicks: result.UniqueClicks,
+        Banners:      make([]*campaign.CampaignCounterResponse_BannerCounter, len(result.Banners)),
+    }
+    for i, banner := range result.Banners {
+        response.Banners[i] = &campaign.CampaignCounterResponse_BannerCounter{
+            BannerId:    banner.BannerID,
+            TotalClicks: banner.TotalClicks,
+        }
+    }
+    return response, nil
+}
+
+func (h *CampaignHandler) CampaignStats(ctx context.Context, req *campaign.CampaignStatsRequest) (*campaign.CampaignStatsResponse, error) {
+    if req.TsFrom >= req.TsTo {
+        return nil, status.Error(codes.InvalidArgument, "ts_from must be less than ts_to")
+    }
+
+    result, err := h.useCase.Stats(ctx, req.CampaignId, repository.StatsFilter{
+        From:               time.Unix(req.TsFrom, 0),
+        To:                 time.Unix(req.TsTo, 0),
+        IncludeOutOfFlight: req.IncludeOutOfFlight,
+        IncludeInvalid:     req.IncludeInvalid,
+    })
+    if err != nil {
+        return nil, statusError(err)
+    }
+
+    response := &campaign.CampaignStatsResponse{
+        TotalClicks:  result.TotalClicks,
+        UniqueClicks: result.UniqueClicks,
+        Stats:        toCampaignClickStats(result.Stats),
+        Banners:      make([]*campaign.CampaignStatsResponse_BannerStats, len(result.Banners)),
+    }
+    for i, banner := range result.Banners {
+        response.Banners[i] = &campaign.CampaignStatsResponse_BannerStats{
+            BannerId:    banner.BannerID,
+            TotalClicks: banner.TotalClicks,
+            Stats:       toCampaignClickStats(banner.Stats),
+        }
+    }
+    return response, nil
+}
+
+func toCampaignProto(c *entity.Campaign) *campaign.Campaign {
+    return &campaign.Campaign{
+        Id:        c.ID,
+        Name:      c.Name,
+        BannerIds: c.BannerIDs,
+        CreatedAt: c.CreatedAt.Unix(),
+    }
+}
+
+func toCampaignClickStats(clicks []*entity.Click) []*campaign.CampaignStatsResponse_ClickStats {
+    stats := make([]*campaign.CampaignStatsResponse_ClickStats, len(clicks))
+    for i, click := range clicks {
+        stats[i] = &campaign.CampaignStatsResponse_ClickStats{
+            Timestamp:   click.Timestamp.Unix(),
+            Count:       int32(click.Count),
+            OutOfFlight:   click.OutOfFlight,
+            InvalidReason: string(click.RejectReason),
+        }
+    }
+    return stats
+}
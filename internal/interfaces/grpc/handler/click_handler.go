@@ -4,7 +4,9 @@ import (
     "context"
     "time"
 
+    "clicker/internal/domain/entity"
     "clicker/internal/domain/repository"
+    "clicker/internal/interfaces/grpc/clientinfo"
     "clicker/pkg/counter"
     "clicker/pkg/stats"
 )
@@ -20,17 +22,45 @@ func NewClickHandler(useCase repository.ClickUseCase) *ClickHandler {
 }
 
 func (h *ClickHandler) Counter(ctx context.Context, req *counter.CounterRequest) (*counter.CounterResponse, error) {
-    total, err := h.useCase.Counter(ctx, req.BannerId)
+    result, err := h.useCase.Counter(ctx, &entity.ClickRequest{
+        BannerID:  req.BannerId,
+        Token:     req.Token,
+        SessionID: req.SessionId,
+        IP:        clientinfo.IP(ctx),
+        UserAgent: clientinfo.UserAgent(ctx),
+    })
     if err != nil {
-        return nil, err
+        return nil, statusError(err)
+    }
+    return &counter.CounterResponse{
+        TotalClicks:  result.TotalClicks,
+        OutOfFlight:  result.OutOfFlight,
+        Status:       toClickStatusProto(result.Status),
+        UniqueClicks: result.UniqueClicks,
+        ClickId:      result.ClickID,
+    }, nil
+}
+
+func (h *ClickHandler) IssueClickToken(ctx context.Context, req *counter.IssueClickTokenRequest) (*counter.ClickToken, error) {
+    token, err := h.useCase.IssueToken(ctx, req.BannerId, req.Placement)
+    if err != nil {
+        return nil, statusError(err)
     }
-    return &counter.CounterResponse{TotalClicks: total}, nil
+    return &counter.ClickToken{
+        Token:     token.Value,
+        BannerId:  token.BannerID,
+        Placement: token.Placement,
+        ExpiresAt: token.ExpiresAt.Unix(),
+    }, nil
 }
 
 func (h *ClickHandler) Stats(ctx context.Context, req *stats.StatsRequest) (*stats.StatsResponse, error) {
-    clicks, err := h.useCase.Stats(ctx, req.BannerId, 
-        time.Unix(req.TsFrom, 0), 
-        time.Unix(req.TsTo, 0))
+    clicks, err := h.useCase.Stats(ctx, req.BannerId, repository.StatsFilter{
+        From:               time.Unix(req.TsFrom, 0),
+        To:                 time.Unix(req.TsTo, 0),
+        IncludeOutOfFlight: req.IncludeOutOfFlight,
+        IncludeInvalid:     req.IncludeInvalid,
+    })
     if err != nil {
         return nil, err
     }
@@ -42,7 +72,20 @@ func (h *ClickHandler) Stats(ctx context.Context, req *stats.StatsRequest) (*sta
         response.Stats[i] = &stats.StatsResponse_ClickStats{
             Timestamp: click.Timestamp.Unix(),
             Count:    int32(click.Count),
+            OutOfFlight: click.OutOfFlight,
+            InvalidReason: string(click.RejectReason),
         }
     }
     return response, nil
 }
+
+func toClickStatusProto(status entity.ClickStatus) counter.ClickStatus {
+    switch status {
+    case entity.ClickDailyCapReached:
+        return counter.ClickStatus_CLICK_STATUS_DAILY_CAP_REACHED
+    case entity.ClickLifetimeCapReached:
+        return counter.ClickStatus_CLICK_STATUS_LIFETIME_CAP_REACHED
+    default:
+        return counter.ClickStatus_CLICK_STATUS_ACCEPTED
+    }
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+    "context"
+
+    "clicker/internal/domain/entity"
+    "clicker/internal/domain/repository"
+    "clicker/pkg/conversion"
+)
+
+type ConversionHandler struct {
+    conversion.UnimplementedConversionServiceServer
+    useCase repository.ConversionUseCase
+}
+
+func NewConversionHandler(useCase repository.ConversionUseCase) *ConversionHandler {
+    return &ConversionHandler{
+        useCase: useCase,
+    }
+}
+
+func (h *ConversionHandler) RegisterConversion(ctx context.Context, req *conversion.RegisterConversionRequest) (*conversion.Conversion, error) {
+    c, err := h.useCase.Register(ctx, &entity.Conversion{
+        ClickID:       req.ClickId,
+        Value:         req.Value,
+        Currency:      req.Currency,
+        TransactionID: req.TransactionId,
+    })
+    if err != nil {
+        return nil, statusError(err)
+    }
+    return &conversion.Conversion{
+        Id:            c.ID,
+        ClickId:       c.ClickID,
+        BannerId:      c.BannerID,
+        Timestamp:     c.Timestamp.Unix(),
+        Value:         c.Value,
+        Currency:      c.Currency,
+        TransactionId: c.TransactionID,
+    }, nil
+}
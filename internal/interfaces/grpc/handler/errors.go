@@ -0,0 +1,40 @@
+package handler
+
+import (
+    "errors"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// statusError maps domain errors returned by use cases onto gRPC status
+// codes. Anything unrecognised is reported as Internal.
+func statusError(err error) error {
+    switch {
+    case errors.Is(err, auth.ErrUnauthenticated):
+        return status.Error(codes.Unauthenticated, err.Error())
+    case errors.Is(err, auth.ErrPermissionDenied):
+        return status.Error(codes.PermissionDenied, err.Error())
+    case errors.Is(err, entity.ErrBannerNotFound), errors.Is(err, entity.ErrCampaignNotFound),
+        errors.Is(err, entity.ErrAPIKeyNotFound), errors.Is(err, entity.ErrClickNotFound),
+        errors.Is(err, entity.ErrAlertRuleNotFound), errors.Is(err, entity.ErrBatcherNotFound):
+        return status.Error(codes.NotFound, err.Error())
+    case errors.Is(err, entity.ErrInvalidFlight), errors.Is(err, entity.ErrInvalidCaps),
+        errors.Is(err, auth.ErrUnknownScope), errors.Is(err, auth.ErrPublishableScope),
+        errors.Is(err, entity.ErrInvalidClickToken), errors.Is(err, entity.ErrInvalidTimeRange),
+        errors.Is(err, entity.ErrInvalidTargetURL), errors.Is(err, entity.ErrRedirectNotAllowed),
+        errors.Is(err, entity.ErrInvalidConversion), errors.Is(err, entity.ErrInvalidAlertRule),
+        errors.Is(err, entity.ErrInvalidBatchSettings):
+        return status.Error(codes.InvalidArgument, err.Error())
+    case errors.Is(err, entity.ErrOutOfFlight), errors.Is(err, entity.ErrClickTokenExpired),
+        errors.Is(err, entity.ErrClickTokensDisabled), errors.Is(err, entity.ErrNoTargetURL),
+        errors.Is(err, entity.ErrConversionOutsideWindow), errors.Is(err, entity.ErrInvalidClick):
+        return status.Error(codes.FailedPrecondition, err.Error())
+    case errors.Is(err, entity.ErrDuplicateConversion):
+        return status.Error(codes.AlreadyExists, err.Error())
+    default:
+        return status.Error(codes.Internal, err.Error())
+    }
+}
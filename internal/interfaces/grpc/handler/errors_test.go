@@ -0,0 +1,32 @@
+package handler
+
+import (
+    "errors"
+    "fmt"
+    "testing"
+
+    "clicker/internal/auth"
+    "clicker/internal/domain/entity"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+    tests := []struct {
+        err  error
+        want codes.Code
+    }{
+        {auth.ErrUnauthenticated, codes.Unauthenticated},
+        {entity.ErrBannerNotFound, codes.NotFound},
+        {entity.ErrInvalidTimeRange, codes.InvalidArgument},
+        {fmt.Errorf("%w: click id is required", entity.ErrInvalidConversion), codes.InvalidArgument},
+        {entity.ErrInvalidClick, codes.FailedPrecondition},
+        {entity.ErrDuplicateConversion, codes.AlreadyExists},
+        {errors.New("connection refused"), codes.Internal},
+    }
+    for _, tt := range tests {
+        if got := status.Code(statusError(tt.err)); got != tt.want {
+            t.Errorf("statusError(%v) = %s, want %s", tt.err, got, tt.want)
+        }
+    }
+}
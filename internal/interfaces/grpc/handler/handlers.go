@@ -1,7 +1,14 @@
 package handler
 
 import (
+	"clicker/pkg/admin"
+	"clicker/pkg/alert"
+	"clicker/pkg/apikey"
+	"clicker/pkg/banner"
+	"clicker/pkg/campaign"
+	"clicker/pkg/conversion"
 	"clicker/pkg/counter"
+	"clicker/pkg/impression"
 	"clicker/pkg/stats"
 	"google.golang.org/grpc"
 )
@@ -11,18 +18,61 @@ type Handler interface {
 }
 
 type GRPCHandler struct {
-	clickHandler *ClickHandler
-	statsHandler *StatsHandler
+	clickHandler      *ClickHandler
+	statsHandler      *StatsHandler
+	campaignHandler   *CampaignHandler
+	bannerHandler     *BannerHandler
+	apiKeyHandler     *APIKeyHandler
+	impressionHandler *ImpressionHandler
+	conversionHandler *ConversionHandler
+	alertHandler      *AlertHandler
+	adminHandler      *AdminHandler
 }
 
-func NewHandler(clickHandler *ClickHandler, statsHandler *StatsHandler) Handler {
+func NewHandler(clickHandler *ClickHandler, statsHandler *StatsHandler, campaignHandler *CampaignHandler,
+	bannerHandler *BannerHandler, apiKeyHandler *APIKeyHandler, impressionHandler *ImpressionHandler,
+	conversionHandler *ConversionHandler, alertHandler *AlertHandler, adminHandler *AdminHandler) Handler {
 	return &GRPCHandler{
-		clickHandler: clickHandler,
-		statsHandler: statsHandler,
+		clickHandler:      clickHandler,
+		statsHandler:      statsHandler,
+		campaignHandler:   campaignHandler,
+		bannerHandler:     bannerHandler,
+		apiKeyHandler:     apiKeyHandler,
+		impressionHandler: impressionHandler,
+		conversionHandler: conversionHandler,
+		alertHandler:      alertHandler,
+		adminHandler:      adminHandler,
 	}
 }
 
+// Register registers the services whose handlers are set, so that a process
+// only serves the services it runs.
 func (h *GRPCHandler) Register(grpcServer *grpc.Server) {
-	counter.RegisterCounterServiceServer(grpcServer, h.clickHandler)
-	stats.RegisterStatsServiceServer(grpcServer, h.statsHandler)
+	if h.clickHandler != nil {
+		counter.RegisterCounterServiceServer(grpcServer, h.clickHandler)
+	}
+	if h.statsHandler != nil {
+		stats.RegisterStatsServiceServer(grpcServer, h.statsHandler)
+	}
+	if h.campaignHandler != nil {
+		campaign.RegisterCampaignServiceServer(grpcServer, h.campaignHandler)
+	}
+	if h.bannerHandler != nil {
+		banner.RegisterBannerServiceServer(grpcServer, h.bannerHandler)
+	}
+	if h.apiKeyHandler != nil {
+		apikey.RegisterApiKeyServiceServer(grpcServer, h.apiKeyHandler)
+	}
+	if h.impressionHandler != nil {
+		impression.RegisterImpressionServiceServer(grpcServer, h.impressionHandler)
+	}
+	if h.conversionHandler != nil {
+		conversion.RegisterConversionServiceServer(grpcServer, h.conversionHandler)
+	}
+	if h.alertHandler != nil {
+		alert.RegisterAlertServiceServer(grpcServer, h.alertHandler)
+	}
+	if h.adminHandler != nil {
+		admin.RegisterAdminServiceServer(grpcServer, h.adminHandler)
+	}
 }
@@ -0,0 +1,26 @@
+package handler
+
+import (
+    "context"
+
+    "clicker/internal/domain/repository"
+    "clicker/pkg/impression"
+)
+
+type ImpressionHandler struct {
+    impression.UnimplementedImpressionServiceServer
+    useCase repository.ImpressionUseCase
+}
+
+func NewImpressionHandler(useCase repository.ImpressionUseCase) *ImpressionHandler {
+    return &ImpressionHandler{
+        useCase: useCase,
+    }
+}
+
+func (h *ImpressionHandler) Impression(ctx context.Context, req *impression.ImpressionRequest) (*impression.ImpressionResponse, error) {
+    if err := h.useCase.Register(ctx, req.BannerId); err != nil {
+        return nil, statusError(err)
+    }
+    return &impression.ImpressionResponse{}, nil
+}
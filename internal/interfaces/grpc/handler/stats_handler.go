@@ -2,14 +2,18 @@ package handler
 
 import (
     "context"
+    "sort"
     "time"
 
+    "clicker/internal/domain/entity"
     "clicker/internal/domain/repository"
     "clicker/pkg/stats"
     "google.golang.org/grpc/codes"
     "google.golang.org/grpc/status"
 )
 
+const defaultStatsBucket = time.Hour
+
 type StatsHandler struct {
     stats.UnimplementedStatsServiceServer
     useCase repository.StatsUseCase
@@ -25,12 +29,24 @@ func (h *StatsHandler) Stats(ctx context.Context, req *stats.StatsRequest) (*sta
     if req.TsFrom >= req.TsTo {
         return nil, status.Error(codes.InvalidArgument, "ts_from must be less than ts_to")
     }
+    if req.BucketSeconds < 0 {
+        return nil, status.Error(codes.InvalidArgument, "bucket_seconds must not be negative")
+    }
+    bucket := defaultStatsBucket
+    if req.BucketSeconds > 0 {
+        bucket = time.Duration(req.BucketSeconds) * time.Second
+    }
 
-    clicks, err := h.useCase.GetStats(ctx, req.BannerId,
-        time.Unix(req.TsFrom, 0),
-        time.Unix(req.TsTo, 0))
+    filter := repository.StatsFilter{
+        From:               time.Unix(req.TsFrom, 0),
+        To:                 time.Unix(req.TsTo, 0),
+        IncludeOutOfFlight: req.IncludeOutOfFlight,
+        IncludeInvalid:     req.IncludeInvalid,
+    }
+
+    clicks, err := h.useCase.GetStats(ctx, req.BannerId, filter)
     if err != nil {
-        return nil, status.Error(codes.Internal, err.Error())
+        return nil, statusError(err)
     }
 
     response := &stats.StatsResponse{
@@ -41,8 +57,66 @@ func (h *StatsHandler) Stats(ctx context.Context, req *stats.StatsRequest) (*sta
         response.Stats[i] = &stats.StatsResponse_ClickStats{
             Timestamp: click.Timestamp.Unix(),
             Count:    int32(click.Count),
+            OutOfFlight: click.OutOfFlight,
+            InvalidReason: string(click.RejectReason),
+        }
+    }
+
+    response.UniqueClicks, err = h.useCase.GetUniqueClicks(ctx, req.BannerId, filter)
+    if err != nil {
+        return nil, statusError(err)
+    }
+
+    buckets, err := h.useCase.GetBuckets(ctx, req.BannerId, filter, bucket)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    total := &entity.StatsBucket{Revenue: make(map[string]float64)}
+    for _, b := range buckets {
+        response.Buckets = append(response.Buckets, &stats.StatsResponse_Bucket{
+            Timestamp:      b.Timestamp.Unix(),
+            Clicks:         b.Clicks,
+            Impressions:    b.Impressions,
+            Ctr:            b.CTR(),
+            Conversions:    b.Conversions,
+            ConversionRate: b.ConversionRate(),
+            Revenue:        toRevenueProto(b.Revenue),
+        })
+        total.Clicks += b.Clicks
+        total.Conversions += b.Conversions
+        for currency, value := range b.Revenue {
+            total.Revenue[currency] += value
         }
     }
+    response.Conversions = total.Conversions
+    response.ConversionRate = total.ConversionRate()
+    response.Revenue = toRevenueProto(total.Revenue)
+
+    rejected, err := h.useCase.GetRejectedClicks(ctx, req.BannerId, filter)
+    if err != nil {
+        return nil, statusError(err)
+    }
+    for _, r := range rejected {
+        response.Rejected = append(response.Rejected, &stats.StatsResponse_RejectedClicks{
+            Reason: string(r.Reason),
+            Count:  r.Count,
+        })
+    }
 
     return response, nil
 }
+
+// toRevenueProto lists revenue per currency, ordered by currency.
+func toRevenueProto(revenue map[string]float64) []*stats.StatsResponse_Revenue {
+    currencies := make([]string, 0, len(revenue))
+    for currency := range revenue {
+        currencies = append(currencies, currency)
+    }
+    sort.Strings(currencies)
+
+    result := make([]*stats.StatsResponse_Revenue, len(currencies))
+    for i, currency := range currencies {
+        result[i] = &stats.StatsResponse_Revenue{Currency: currency, Value: revenue[currency]}
+    }
+    return result
+}
@@ -0,0 +1,109 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"clicker/internal/auth"
+	"clicker/internal/domain/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader is the metadata key, and the HTTP header forwarded by the
+// gateway, that may carry an API key instead of "authorization".
+const APIKeyHeader = "x-api-key"
+
+// Auth authenticates every incoming call with the API key or JWT it carries,
+// checks the caller's scopes against the method being called and stores the resulting
+// identity in the call context.
+type Auth struct {
+	useCase repository.AuthUseCase
+}
+
+func NewAuth(useCase repository.AuthUseCase) *Auth {
+	return &Auth{useCase: useCase}
+}
+
+func (a *Auth) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Auth) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (a *Auth) authorize(ctx context.Context, method string) (context.Context, error) {
+	if publicMethods[method] {
+		return ctx, nil
+	}
+	identity, err := a.useCase.Authenticate(ctx, apiKey(ctx))
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// Publishable keys are public, so they only count pixels and redirects.
+	if identity.Publishable {
+		return nil, status.Error(codes.PermissionDenied, "publishable keys are only accepted by the pixel and redirect endpoints")
+	}
+
+	scope, ok := methodScopes[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed", method)
+	}
+	if !identity.HasScope(scope) {
+		if identity.Role != "" {
+			return nil, status.Errorf(codes.PermissionDenied, "role %q may not call %s", identity.Role, method)
+		}
+		return nil, status.Errorf(codes.PermissionDenied, "%s requires scope %q", method, scope)
+	}
+
+	return auth.WithIdentity(ctx, identity), nil
+}
+
+// apiKey takes the key from "x-api-key" or, failing that, from a bearer
+// "authorization" value, which may also hold a JWT.
+func apiKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(APIKeyHeader); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(value, " ")
+		if found && strings.EqualFold(scheme, "bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// wrappedStream overrides the context of a server stream so that values
+// added by interceptors reach the handler.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
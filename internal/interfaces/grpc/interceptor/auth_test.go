@@ -0,0 +1,75 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"clicker/internal/auth"
+	"clicker/pkg/admin"
+	"clicker/pkg/apikey"
+	"clicker/pkg/campaign"
+	"clicker/pkg/counter"
+	"clicker/pkg/stats"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuth knows a fixed set of keys.
+type fakeAuth map[string]*auth.Identity
+
+func (f fakeAuth) Authenticate(_ context.Context, key string) (*auth.Identity, error) {
+	identity, ok := f[key]
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+	return identity, nil
+}
+
+func TestAuthorize(t *testing.T) {
+	a := NewAuth(fakeAuth{
+		"ck_writer": {TenantID: 1, KeyID: 1, Scopes: []auth.Scope{auth.ScopeClicksWrite}},
+		"ck_reader": {TenantID: 1, KeyID: 2, Scopes: []auth.Scope{auth.ScopeStatsRead, auth.ScopeCampaignsRead}},
+		"ck_all":    {TenantID: 1, KeyID: 3, Scopes: auth.AllScopes},
+		"operator":  {Operator: true},
+		"pk_pixel":  {TenantID: 1, KeyID: 4, Scopes: []auth.Scope{auth.ScopeClicksWrite}, Publishable: true},
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		md     metadata.MD
+		want   codes.Code
+	}{
+		{"no credentials", counter.CounterService_Counter_FullMethodName, nil, codes.Unauthenticated},
+		{"unknown key", counter.CounterService_Counter_FullMethodName, metadata.Pairs(APIKeyHeader, "ck_other"), codes.Unauthenticated},
+		{"scope held", counter.CounterService_Counter_FullMethodName, metadata.Pairs(APIKeyHeader, "ck_writer"), codes.OK},
+		{"bearer key", stats.StatsService_Stats_FullMethodName, metadata.Pairs("authorization", "Bearer ck_reader"), codes.OK},
+		{"scope missing", stats.StatsService_Stats_FullMethodName, metadata.Pairs(APIKeyHeader, "ck_writer"), codes.PermissionDenied},
+		{"read scope cannot write", campaign.CampaignService_CreateCampaign_FullMethodName, metadata.Pairs(APIKeyHeader, "ck_reader"), codes.PermissionDenied},
+		{"keys need their own scope", apikey.ApiKeyService_IssueApiKey_FullMethodName, metadata.Pairs(APIKeyHeader, "ck_reader"), codes.PermissionDenied},
+		{"unlisted method is denied", "/clicker.Unknown/Call", metadata.Pairs(APIKeyHeader, "ck_all"), codes.PermissionDenied},
+		{"publishable keys are refused", counter.CounterService_Counter_FullMethodName, metadata.Pairs(APIKeyHeader, "pk_pixel"), codes.PermissionDenied},
+		{"tenants cannot administer the service", admin.AdminService_UpdateBatcher_FullMethodName, metadata.Pairs(APIKeyHeader, "ck_all"), codes.PermissionDenied},
+		{"operator administers the service", admin.AdminService_UpdateBatcher_FullMethodName, metadata.Pairs(APIKeyHeader, "operator"), codes.OK},
+		{"health is public", healthpb.Health_Check_FullMethodName, nil, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			ctx, err := a.authorize(ctx, tt.method)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("authorize = %v, want %s", err, tt.want)
+			}
+			if err == nil && !publicMethods[tt.method] {
+				if _, ok := auth.FromContext(ctx); !ok {
+					t.Error("authorize did not store the identity")
+				}
+			}
+		})
+	}
+}
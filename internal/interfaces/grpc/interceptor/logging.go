@@ -0,0 +1,81 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"clicker/internal/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var requestIDKey = strings.ToLower(logging.RequestIDHeader)
+
+// Logging assigns every call a request id, taken from the "x-request-id"
+// metadata the gateway forwards or generated for direct gRPC callers, and
+// writes an access log line when the call completes. It comes first in the
+// chain so that every later log line carries the request id.
+type Logging struct {
+	logger *slog.Logger
+}
+
+func NewLogging(logger *slog.Logger) *Logging {
+	return &Logging{logger: logger}
+}
+
+func (l *Logging) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withRequestID(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, logging.RequestID(ctx)))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		l.log(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func (l *Logging) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestID(ss.Context())
+		ss.SetHeader(metadata.Pairs(requestIDKey, logging.RequestID(ctx)))
+
+		start := time.Now()
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+		l.log(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (l *Logging) log(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = slog.LevelError
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	l.logger.LogAttrs(ctx, level, "grpc request", attrs...)
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	return logging.WithRequestID(ctx, logging.EnsureRequestID(id))
+}
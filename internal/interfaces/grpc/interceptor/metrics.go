@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"clicker/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics records the count, outcome and latency of every RPC. It comes
+// first in the chain so that calls refused by authentication or rate
+// limiting are counted as well.
+type Metrics struct{}
+
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+func (m *Metrics) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done := observe("unary", info.FullMethod)
+		resp, err := handler(ctx, req)
+		done(err)
+		return resp, err
+	}
+}
+
+func (m *Metrics) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		rpcType := "bidi_stream"
+		switch {
+		case info.IsServerStream && !info.IsClientStream:
+			rpcType = "server_stream"
+		case info.IsClientStream && !info.IsServerStream:
+			rpcType = "client_stream"
+		}
+
+		done := observe(rpcType, info.FullMethod)
+		err := handler(srv, ss)
+		done(err)
+		return err
+	}
+}
+
+// observe counts a started call and returns the function that records its
+// completion.
+func observe(rpcType, fullMethod string) func(error) {
+	service, method := splitMethod(fullMethod)
+	metrics.GRPCStarted.WithLabelValues(rpcType, service, method).Inc()
+	start := time.Now()
+
+	return func(err error) {
+		metrics.GRPCHandled.WithLabelValues(rpcType, service, method, status.Code(err).String()).Inc()
+		metrics.GRPCHandlingSeconds.WithLabelValues(rpcType, service, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", "unknown"
+	}
+	return service, method
+}
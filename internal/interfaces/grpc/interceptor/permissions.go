@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"clicker/internal/auth"
+	"clicker/pkg/admin"
+	"clicker/pkg/alert"
+	"clicker/pkg/apikey"
+	"clicker/pkg/banner"
+	"clicker/pkg/campaign"
+	"clicker/pkg/conversion"
+	"clicker/pkg/counter"
+	"clicker/pkg/impression"
+	"clicker/pkg/stats"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// methodScopes lists the scope required by every RPC. Methods missing from
+// the table are denied, so a new RPC stays closed until it is added here.
+var methodScopes = map[string]auth.Scope{
+	counter.CounterService_Counter_FullMethodName:         auth.ScopeClicksWrite,
+	counter.CounterService_IssueClickToken_FullMethodName: auth.ScopeClickTokens,
+
+	stats.StatsService_Stats_FullMethodName: auth.ScopeStatsRead,
+
+	impression.ImpressionService_Impression_FullMethodName: auth.ScopeImpressionsWrite,
+
+	conversion.ConversionService_RegisterConversion_FullMethodName: auth.ScopeConversionsWrite,
+
+	campaign.CampaignService_CreateCampaign_FullMethodName:        auth.ScopeCampaignsWrite,
+	campaign.CampaignService_GetCampaign_FullMethodName:           auth.ScopeCampaignsRead,
+	campaign.CampaignService_ListCampaigns_FullMethodName:         auth.ScopeCampaignsRead,
+	campaign.CampaignService_UpdateCampaign_FullMethodName:        auth.ScopeCampaignsWrite,
+	campaign.CampaignService_DeleteCampaign_FullMethodName:        auth.ScopeCampaignsWrite,
+	campaign.CampaignService_AddCampaignBanners_FullMethodName:    auth.ScopeCampaignsWrite,
+	campaign.CampaignService_RemoveCampaignBanners_FullMethodName: auth.ScopeCampaignsWrite,
+	campaign.CampaignService_CampaignCounter_FullMethodName:       auth.ScopeStatsRead,
+	campaign.CampaignService_CampaignStats_FullMethodName:         auth.ScopeStatsRead,
+
+	banner.BannerService_GetBanner_FullMethodName:          auth.ScopeBannersRead,
+	banner.BannerService_SetBannerFlight_FullMethodName:    auth.ScopeBannersWrite,
+	banner.BannerService_SetBannerCaps_FullMethodName:      auth.ScopeBannersWrite,
+	banner.BannerService_SetBannerTargetURL_FullMethodName: auth.ScopeBannersWrite,
+	banner.BannerService_WatchCapEvents_FullMethodName:     auth.ScopeBannersRead,
+
+	alert.AlertService_CreateAlertRule_FullMethodName: auth.ScopeAlertsManage,
+	alert.AlertService_ListAlertRules_FullMethodName:  auth.ScopeAlertsManage,
+	alert.AlertService_DeleteAlertRule_FullMethodName: auth.ScopeAlertsManage,
+
+	admin.AdminService_ListBatchers_FullMethodName:  auth.ScopeOperator,
+	admin.AdminService_UpdateBatcher_FullMethodName: auth.ScopeOperator,
+
+	apikey.ApiKeyService_IssueApiKey_FullMethodName:  auth.ScopeKeysManage,
+	apikey.ApiKeyService_ListApiKeys_FullMethodName:  auth.ScopeKeysManage,
+	apikey.ApiKeyService_RevokeApiKey_FullMethodName: auth.ScopeKeysManage,
+}
+
+// publicMethods are served without credentials, so that probes and load
+// balancers can use the gRPC health service.
+var publicMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+	healthpb.Health_Watch_FullMethodName: true,
+}
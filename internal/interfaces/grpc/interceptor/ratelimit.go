@@ -0,0 +1,299 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"clicker/internal/auth"
+	"clicker/internal/interfaces/grpc/clientinfo"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RetryAfterHeader carries the number of seconds a throttled client should
+// wait. The gateway forwards it as the HTTP "Retry-After" header.
+const RetryAfterHeader = "retry-after"
+
+// LimitKey selects what a rate limit is counted against.
+type LimitKey string
+
+const (
+	LimitByKey    LimitKey = "key"
+	LimitByTenant LimitKey = "tenant"
+	LimitByIP     LimitKey = "ip"
+)
+
+// Limit is a token bucket refilled with Rate tokens per second and holding
+// at most Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+	Key   LimitKey
+}
+
+// bucketIdleTTL is how long an unused bucket is kept. A bucket idle for
+// longer is full again anyway, so dropping it changes nothing.
+const bucketIdleTTL = 10 * time.Minute
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimit throttles calls per client with one token bucket per RPC, limit
+// and client. Its IP limits run before Auth, so that calls with bad or no
+// credentials are throttled before they cost a key lookup; its key and
+// tenant limits run after Auth, whose identity they read.
+type RateLimit struct {
+	limits   map[string][]Limit
+	fallback []Limit
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// ParseRateLimits parses a spec such as
+//
+//	Counter=100:200:key,200:400:ip;Stats=5:10:tenant;*=50:100:ip
+//
+// where every entry is "<rpc>=<limit>[,<limit>]..." and every limit is
+// "<rate per second>:<burst>:<key|tenant|ip>", at most one of each kind per
+// entry. The RPC is either its name or its full method name; "*" applies to
+// every RPC without its own entry.
+func ParseRateLimits(spec string) (map[string][]Limit, error) {
+	limits := make(map[string][]Limit)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		method, value, ok := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !ok || method == "" {
+			return nil, fmt.Errorf("invalid rate limit %q", entry)
+		}
+
+		var list []Limit
+		for _, part := range strings.Split(value, ",") {
+			limit, err := parseLimit(strings.TrimSpace(part), entry)
+			if err != nil {
+				return nil, err
+			}
+			for _, other := range list {
+				if other.Key == limit.Key {
+					return nil, fmt.Errorf("two %s limits in %q", limit.Key, entry)
+				}
+			}
+			list = append(list, limit)
+		}
+		limits[method] = list
+	}
+	return limits, nil
+}
+
+func parseLimit(value, entry string) (Limit, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q", entry)
+	}
+
+	r, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || r <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate in %q", entry)
+	}
+	burst, err := strconv.Atoi(parts[1])
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("invalid burst in %q", entry)
+	}
+	key := LimitKey(parts[2])
+	if key != LimitByKey && key != LimitByTenant && key != LimitByIP {
+		return Limit{}, fmt.Errorf("invalid limit key in %q", entry)
+	}
+	return Limit{Rate: r, Burst: burst, Key: key}, nil
+}
+
+func NewRateLimit(limits map[string][]Limit) *RateLimit {
+	rl := &RateLimit{
+		limits:  make(map[string][]Limit, len(limits)),
+		buckets: make(map[string]*bucket),
+	}
+	for method, list := range limits {
+		if method == "*" {
+			rl.fallback = list
+			continue
+		}
+		rl.limits[method] = list
+	}
+	return rl
+}
+
+// UnaryByIP applies the IP limits and goes before Auth in the chain.
+func (rl *RateLimit) UnaryByIP() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if wait, ok := rl.AllowIP(info.FullMethod, clientinfo.IP(ctx)); !ok {
+			grpc.SetHeader(ctx, retryAfter(wait))
+			return nil, throttled(info.FullMethod, wait)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamByIP applies the IP limits and goes before Auth in the chain.
+func (rl *RateLimit) StreamByIP() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if wait, ok := rl.AllowIP(info.FullMethod, clientinfo.IP(ss.Context())); !ok {
+			ss.SetHeader(retryAfter(wait))
+			return throttled(info.FullMethod, wait)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Unary applies the key and tenant limits and goes after Auth.
+func (rl *RateLimit) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if wait, ok := rl.Allow(ctx, info.FullMethod, clientinfo.IP(ctx)); !ok {
+			grpc.SetHeader(ctx, retryAfter(wait))
+			return nil, throttled(info.FullMethod, wait)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream applies the key and tenant limits and goes after Auth.
+func (rl *RateLimit) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if wait, ok := rl.Allow(ss.Context(), info.FullMethod, clientinfo.IP(ss.Context())); !ok {
+			ss.SetHeader(retryAfter(wait))
+			return throttled(info.FullMethod, wait)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// AllowIP takes a token from every IP limit of method for a call from ip
+// and, when one of them has none left, reports how long the client has to
+// wait for the next one. The plain HTTP endpoints share the limits of the
+// RPCs by calling it, and Allow, with a name of their own.
+func (rl *RateLimit) AllowIP(method, ip string) (time.Duration, bool) {
+	return rl.take(method, func(limit Limit) (string, bool) {
+		return "ip:" + ip, limit.Key == LimitByIP
+	})
+}
+
+// Allow does what AllowIP does for the key and tenant limits of method,
+// counted against the caller in ctx. Callers without an identity are
+// counted by ip.
+func (rl *RateLimit) Allow(ctx context.Context, method, ip string) (time.Duration, bool) {
+	return rl.take(method, func(limit Limit) (string, bool) {
+		return clientKey(ctx, limit.Key, ip), limit.Key != LimitByIP
+	})
+}
+
+// take reserves a token in the bucket of the client for every limit of
+// method that client selects. When a bucket is empty, it hands all tokens
+// back and reports the longest wait.
+func (rl *RateLimit) take(method string, client func(Limit) (string, bool)) (time.Duration, bool) {
+	now := time.Now()
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.sweep(now)
+
+	var reservations []*rate.Reservation
+	var wait time.Duration
+	for _, limit := range rl.limitsFor(method) {
+		key, ok := client(limit)
+		if !ok {
+			continue
+		}
+		name := method + "|" + string(limit.Key) + "|" + key
+
+		b, ok := rl.buckets[name]
+		if !ok {
+			b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+			rl.buckets[name] = b
+		}
+		b.lastSeen = now
+
+		reservation := b.limiter.ReserveN(now, 1)
+		reservations = append(reservations, reservation)
+		if delay := reservation.DelayFrom(now); delay > wait {
+			wait = delay
+		}
+	}
+	if wait > 0 {
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+		return wait, false
+	}
+	return 0, true
+}
+
+func (rl *RateLimit) limitsFor(method string) []Limit {
+	if list, ok := rl.limits[method]; ok {
+		return list
+	}
+	if list, ok := rl.limits[path.Base(method)]; ok {
+		return list
+	}
+	return rl.fallback
+}
+
+// sweep drops idle buckets at most once per bucketIdleTTL. Callers hold mu.
+func (rl *RateLimit) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < bucketIdleTTL {
+		return
+	}
+	rl.lastSweep = now
+	for name, b := range rl.buckets {
+		if now.Sub(b.lastSeen) > bucketIdleTTL {
+			delete(rl.buckets, name)
+		}
+	}
+}
+
+// clientKey identifies the caller for a limit. Key limits fall back to the
+// tenant for callers without an API key, i.e. JWT users are counted by
+// subject, and anything unidentified is counted by IP. The IP has to be
+// resolved through the trusted proxies only, so that a client cannot pick a
+// fresh bucket by sending its own X-Forwarded-For.
+func clientKey(ctx context.Context, key LimitKey, ip string) string {
+	identity, ok := auth.FromContext(ctx)
+	switch {
+	case ok && key == LimitByKey && identity.KeyID != 0:
+		return "key:" + strconv.FormatInt(identity.KeyID, 10)
+	case ok && key == LimitByKey && identity.Subject != "":
+		return "user:" + strconv.FormatInt(identity.TenantID, 10) + ":" + identity.Subject
+	case ok && key != LimitByIP:
+		return "tenant:" + strconv.FormatInt(identity.TenantID, 10)
+	default:
+		return "ip:" + ip
+	}
+}
+
+func retryAfter(wait time.Duration) metadata.MD {
+	seconds := int64(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return metadata.Pairs(RetryAfterHeader, strconv.FormatInt(seconds, 10))
+}
+
+func throttled(method string, wait time.Duration) error {
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s, retry in %s",
+		path.Base(method), wait.Round(time.Millisecond))
+}
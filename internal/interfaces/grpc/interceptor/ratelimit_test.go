@@ -0,0 +1,189 @@
+package interceptor
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"clicker/internal/auth"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    map[string][]Limit
+		wantErr bool
+	}{
+		{spec: "", want: map[string][]Limit{}},
+		{
+			spec: " Counter=100:200:key ; Stats=0.5:1:tenant;*=50:100:ip; ",
+			want: map[string][]Limit{
+				"Counter": {{Rate: 100, Burst: 200, Key: LimitByKey}},
+				"Stats":   {{Rate: 0.5, Burst: 1, Key: LimitByTenant}},
+				"*":       {{Rate: 50, Burst: 100, Key: LimitByIP}},
+			},
+		},
+		{
+			spec: "Counter=100:200:key, 500:1000:ip",
+			want: map[string][]Limit{"Counter": {
+				{Rate: 100, Burst: 200, Key: LimitByKey},
+				{Rate: 500, Burst: 1000, Key: LimitByIP},
+			}},
+		},
+		{
+			spec: "/clicker.CounterService/Counter=1:1:key",
+			want: map[string][]Limit{"/clicker.CounterService/Counter": {{Rate: 1, Burst: 1, Key: LimitByKey}}},
+		},
+		{spec: "Counter", wantErr: true},
+		{spec: "=1:1:key", wantErr: true},
+		{spec: "Counter=", wantErr: true},
+		{spec: "Counter=1:1", wantErr: true},
+		{spec: "Counter=fast:1:key", wantErr: true},
+		{spec: "Counter=0:1:key", wantErr: true},
+		{spec: "Counter=1:0:key", wantErr: true},
+		{spec: "Counter=1:1.5:key", wantErr: true},
+		{spec: "Counter=1:1:user", wantErr: true},
+		{spec: "Counter=1:1:key,", wantErr: true},
+		{spec: "Counter=1:1:ip,2:2:ip", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRateLimits(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseRateLimits(%q) = %v, want an error", tt.spec, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRateLimits(%q): %v", tt.spec, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseRateLimits(%q) = %v, want %v", tt.spec, got, tt.want)
+			continue
+		}
+		for method, limits := range tt.want {
+			if !slices.Equal(got[method], limits) {
+				t.Errorf("ParseRateLimits(%q)[%s] = %+v, want %+v", tt.spec, method, got[method], limits)
+			}
+		}
+	}
+}
+
+func TestLimitsFor(t *testing.T) {
+	rl := NewRateLimit(map[string][]Limit{
+		"Counter":                     {{Rate: 1, Burst: 1, Key: LimitByKey}},
+		"/clicker.StatsService/Stats": {{Rate: 2, Burst: 2, Key: LimitByTenant}},
+		"*":                           {{Rate: 3, Burst: 3, Key: LimitByIP}},
+	})
+	tests := []struct {
+		method string
+		want   float64
+	}{
+		{"/clicker.CounterService/Counter", 1},
+		{"/clicker.StatsService/Stats", 2},
+		{"/clicker.CampaignService/CampaignStats", 3},
+	}
+	for _, tt := range tests {
+		if limits := rl.limitsFor(tt.method); len(limits) != 1 || limits[0].Rate != tt.want {
+			t.Errorf("limitsFor(%s) = %+v, want rate %v", tt.method, limits, tt.want)
+		}
+	}
+
+	if limits := NewRateLimit(nil).limitsFor("/clicker.CounterService/Counter"); len(limits) != 0 {
+		t.Errorf("limitsFor without limits = %+v, want none", limits)
+	}
+}
+
+func withKey(tenantID, keyID int64) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{TenantID: tenantID, KeyID: keyID})
+}
+
+const (
+	counterMethod = "/clicker.CounterService/Counter"
+	statsMethod   = "/clicker.StatsService/Stats"
+)
+
+func TestAllow(t *testing.T) {
+	rl := NewRateLimit(map[string][]Limit{
+		"Counter": {{Rate: 0.001, Burst: 2, Key: LimitByKey}, {Rate: 0.001, Burst: 1, Key: LimitByIP}},
+		"Stats":   {{Rate: 0.001, Burst: 1, Key: LimitByTenant}},
+	})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   bool
+	}{
+		{"first of burst", withKey(1, 1), counterMethod, true},
+		{"second of burst", withKey(1, 1), counterMethod, true},
+		{"burst spent", withKey(1, 1), counterMethod, false},
+		{"other key has its own bucket", withKey(1, 2), counterMethod, true},
+		{"other method has its own bucket", withKey(1, 1), statsMethod, true},
+		{"tenant limit spans keys", withKey(1, 2), statsMethod, false},
+		{"other tenant", withKey(2, 3), statsMethod, true},
+		{"unlimited method", withKey(1, 1), "/clicker.BannerService/GetBanner", true},
+	}
+	for _, tt := range tests {
+		// The IP limit of Counter is left to AllowIP.
+		wait, ok := rl.Allow(tt.ctx, tt.method, "203.0.113.5")
+		if ok != tt.want {
+			t.Errorf("%s: Allow = %v, want %v", tt.name, ok, tt.want)
+		}
+		if !ok && wait <= 0 {
+			t.Errorf("%s: refused without a wait", tt.name)
+		}
+	}
+}
+
+func TestAllowIP(t *testing.T) {
+	rl := NewRateLimit(map[string][]Limit{
+		"Counter": {{Rate: 0.001, Burst: 1, Key: LimitByKey}},
+		"Pixel":   {{Rate: 0.001, Burst: 2, Key: LimitByIP}},
+		"*":       {{Rate: 0.001, Burst: 1, Key: LimitByIP}},
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		ip     string
+		want   bool
+	}{
+		{"first of burst", "Pixel", "203.0.113.5", true},
+		{"second of burst", "Pixel", "203.0.113.5", true},
+		{"burst spent", "Pixel", "203.0.113.5", false},
+		{"other ip", "Pixel", "203.0.113.6", true},
+		{"fallback", statsMethod, "203.0.113.5", true},
+		{"fallback spent", statsMethod, "203.0.113.5", false},
+		{"key limits are left to Allow", counterMethod, "203.0.113.5", true},
+		{"key limits are left to Allow again", counterMethod, "203.0.113.5", true},
+	}
+	for _, tt := range tests {
+		wait, ok := rl.AllowIP(tt.method, tt.ip)
+		if ok != tt.want {
+			t.Errorf("%s: AllowIP = %v, want %v", tt.name, ok, tt.want)
+		}
+		if !ok && wait <= 0 {
+			t.Errorf("%s: refused without a wait", tt.name)
+		}
+	}
+}
+
+func TestAllowHandsTokensBack(t *testing.T) {
+	rl := NewRateLimit(map[string][]Limit{
+		"Counter": {{Rate: 0.001, Burst: 2, Key: LimitByKey}, {Rate: 0.001, Burst: 1, Key: LimitByTenant}},
+	})
+
+	if _, ok := rl.Allow(withKey(1, 1), counterMethod, ""); !ok {
+		t.Fatal("first call refused")
+	}
+	// The tenant bucket is empty, so the key keeps the token it would have
+	// spent on the refused call.
+	if _, ok := rl.Allow(withKey(1, 1), counterMethod, ""); ok {
+		t.Fatal("second call allowed past the tenant limit")
+	}
+	if _, ok := rl.Allow(withKey(2, 1), counterMethod, ""); !ok {
+		t.Error("key lost a token to a refused call")
+	}
+}
@@ -0,0 +1,96 @@
+package httpapi
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"clicker/internal/auth"
+	"clicker/internal/clientip"
+	"clicker/internal/domain/repository"
+)
+
+// Limiter throttles calls per client: AllowIP by address alone, before the
+// caller is known, and Allow by the caller's key or tenant. The gRPC rate
+// limit interceptor is one, so the endpoints here share its limits and
+// buckets.
+type Limiter interface {
+	AllowIP(method, ip string) (time.Duration, bool)
+	Allow(ctx context.Context, method, ip string) (time.Duration, bool)
+}
+
+// KeyKind is the kind of API key an endpoint accepts.
+type KeyKind int
+
+const (
+	// SecretKey endpoints are called by servers, such as the advertiser's
+	// for postbacks, and refuse publishable keys.
+	SecretKey KeyKind = iota
+	// PublishableKey endpoints are linked from pages anyone can read and
+	// refuse secret keys, so that those never end up in a page.
+	PublishableKey
+)
+
+// Guard does for the plain HTTP endpoints what the interceptors do for
+// RPCs: it applies the IP limits of the endpoint, authenticates the API key
+// passed in the "key" query parameter, checks that it is of the kind and
+// holds the scope of the endpoint and applies the key and tenant limits of
+// the endpoint before the request reaches the handler.
+type Guard struct {
+	auth    repository.AuthUseCase
+	limiter Limiter
+	logger  *slog.Logger
+}
+
+func NewGuard(auth repository.AuthUseCase, limiter Limiter, logger *slog.Logger) *Guard {
+	return &Guard{auth: auth, limiter: limiter, logger: logger}
+}
+
+// Wrap guards next, which finds the caller's identity in the request
+// context. endpoint names the rate limit, as an RPC name does for calls.
+func (g *Guard) Wrap(endpoint string, kind KeyKind, scope auth.Scope, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := clientip.FromRequest(r)
+		if wait, ok := g.limiter.AllowIP(endpoint, ip); !ok {
+			throttled(w, endpoint, wait)
+			return
+		}
+
+		identity, err := g.auth.Authenticate(r.Context(), r.URL.Query().Get("key"))
+		if err != nil {
+			writeError(w, r, g.logger, err)
+			return
+		}
+		switch {
+		case kind == PublishableKey && !identity.Publishable:
+			http.Error(w, endpoint+" only accepts publishable keys", http.StatusForbidden)
+			return
+		case kind == SecretKey && identity.Publishable:
+			http.Error(w, endpoint+" does not accept publishable keys", http.StatusForbidden)
+			return
+		}
+		if !identity.HasScope(scope) {
+			http.Error(w, "key lacks scope "+string(scope), http.StatusForbidden)
+			return
+		}
+
+		ctx := auth.WithIdentity(r.Context(), identity)
+		if wait, ok := g.limiter.Allow(ctx, endpoint, ip); !ok {
+			throttled(w, endpoint, wait)
+			return
+		}
+		next(w, r.WithContext(ctx))
+	})
+}
+
+func throttled(w http.ResponseWriter, endpoint string, wait time.Duration) {
+	seconds := int64(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	http.Error(w, "rate limit exceeded for "+endpoint, http.StatusTooManyRequests)
+}
@@ -0,0 +1,118 @@
+package httpapi
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"clicker/internal/auth"
+)
+
+type fakeAuth map[string]*auth.Identity
+
+func (f fakeAuth) Authenticate(ctx context.Context, key string) (*auth.Identity, error) {
+	if identity, ok := f[key]; ok {
+		return identity, nil
+	}
+	return nil, auth.ErrUnauthenticated
+}
+
+// fakeLimiter refuses calls once a client used up its allowance, and every
+// call from a blocked address.
+type fakeLimiter struct {
+	allowance int
+	calls     map[string]int
+	blocked   string
+}
+
+func (f *fakeLimiter) AllowIP(method, ip string) (time.Duration, bool) {
+	if ip == f.blocked {
+		return 500 * time.Millisecond, false
+	}
+	return 0, true
+}
+
+func (f *fakeLimiter) Allow(ctx context.Context, method, ip string) (time.Duration, bool) {
+	identity, _ := auth.FromContext(ctx)
+	client := method + "|" + ip + "|" + identity.Subject
+	f.calls[client]++
+	if f.calls[client] > f.allowance {
+		return 1500 * time.Millisecond, false
+	}
+	return 0, true
+}
+
+func TestGuard(t *testing.T) {
+	keys := fakeAuth{
+		"pixel-key":  {TenantID: 1, Subject: "pixel", Scopes: []auth.Scope{auth.ScopeImpressionsWrite}, Publishable: true},
+		"clicks-key": {TenantID: 1, Subject: "clicks", Scopes: []auth.Scope{auth.ScopeClicksWrite}, Publishable: true},
+		"secret-key": {TenantID: 1, Subject: "secret", Scopes: []auth.Scope{auth.ScopeImpressionsWrite}},
+	}
+	limiter := &fakeLimiter{allowance: 1, calls: make(map[string]int), blocked: "198.51.100.9"}
+	guard := NewGuard(keys, limiter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var seen *auth.Identity
+	h := guard.Wrap("Pixel", PublishableKey, auth.ScopeImpressionsWrite, func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = auth.FromContext(r.Context())
+	})
+
+	tests := []struct {
+		name       string
+		url        string
+		remote     string
+		wantStatus int
+		retryAfter string
+	}{
+		{"missing key", "/pixel/1.gif", "203.0.113.5:1", http.StatusUnauthorized, ""},
+		{"address throttled before the key is checked", "/pixel/1.gif?key=nope", "198.51.100.9:1", http.StatusTooManyRequests, "1"},
+		{"unknown key", "/pixel/1.gif?key=nope", "203.0.113.5:1", http.StatusUnauthorized, ""},
+		{"missing scope", "/pixel/1.gif?key=clicks-key", "203.0.113.5:1", http.StatusForbidden, ""},
+		{"secret key", "/pixel/1.gif?key=secret-key", "203.0.113.5:1", http.StatusForbidden, ""},
+		{"allowed", "/pixel/1.gif?key=pixel-key", "203.0.113.5:1", http.StatusOK, ""},
+		{"throttled", "/pixel/1.gif?key=pixel-key", "203.0.113.5:2", http.StatusTooManyRequests, "2"},
+		{"other client", "/pixel/1.gif?key=pixel-key", "203.0.113.6:1", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			r.RemoteAddr = tt.remote
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.retryAfter)
+			}
+			if reached := seen != nil; reached != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("handler reached = %v", reached)
+			}
+			if seen != nil && seen.Subject != "pixel" {
+				t.Errorf("handler saw identity %+v", seen)
+			}
+		})
+	}
+}
+
+func TestGuardRefusesPublishableKeysOnSecretEndpoints(t *testing.T) {
+	keys := fakeAuth{
+		"publishable": {TenantID: 1, Scopes: []auth.Scope{auth.ScopeConversionsWrite}, Publishable: true},
+	}
+	guard := NewGuard(keys, &fakeLimiter{allowance: 10, calls: make(map[string]int)},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	h := guard.Wrap("Postback", SecretKey, auth.ScopeConversionsWrite, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler reached with a publishable key")
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/postback?key=publishable", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,78 @@
+// Package httpapi serves the plain HTTP endpoints that live next to the
+// gRPC gateway on the REST router.
+package httpapi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"clicker/internal/auth"
+	"clicker/internal/domain/entity"
+	"clicker/internal/domain/repository"
+	"github.com/gorilla/mux"
+)
+
+// transparentGIF is a 1x1 transparent GIF.
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// PixelHandler registers an impression for every request of a tracking
+// pixel. Image requests cannot carry headers, so the API key is passed in
+// the "key" query parameter and must be a publishable key with the
+// impressions:write scope.
+type PixelHandler struct {
+	guard       *Guard
+	impressions repository.ImpressionUseCase
+	logger      *slog.Logger
+}
+
+func NewPixelHandler(guard *Guard, impressions repository.ImpressionUseCase, logger *slog.Logger) *PixelHandler {
+	return &PixelHandler{guard: guard, impressions: impressions, logger: logger}
+}
+
+func (h *PixelHandler) Register(router *mux.Router) {
+	router.Handle("/pixel/{banner_id:[0-9]+}.gif", h.guard.Wrap("Pixel", PublishableKey, auth.ScopeImpressionsWrite, h.serve)).
+		Methods(http.MethodGet)
+}
+
+func (h *PixelHandler) serve(w http.ResponseWriter, r *http.Request) {
+	bannerID, err := strconv.ParseInt(mux.Vars(r)["banner_id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid banner id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.impressions.Register(r.Context(), bannerID); err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/gif")
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+	w.Header().Set("Content-Length", strconv.Itoa(len(transparentGIF)))
+	w.Write(transparentGIF)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, entity.ErrBannerNotFound), errors.Is(err, entity.ErrNoTargetURL),
+		errors.Is(err, entity.ErrClickNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, entity.ErrRedirectNotAllowed), errors.Is(err, entity.ErrInvalidTargetURL):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, entity.ErrInvalidConversion):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, entity.ErrConversionOutsideWindow), errors.Is(err, entity.ErrInvalidClick):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		logger.ErrorContext(r.Context(), "Request failed", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,62 @@
+package httpapi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"clicker/internal/auth"
+	"clicker/internal/domain/entity"
+	"clicker/internal/domain/repository"
+	"github.com/gorilla/mux"
+)
+
+// PostbackHandler registers conversions reported by advertisers as a plain
+// GET postback URL:
+//
+//	/postback?key=<api key>&click_id=<click id>&value=19.99&currency=USD&transaction_id=<order>
+//
+// The API key should only hold the conversions:write scope.
+type PostbackHandler struct {
+	guard       *Guard
+	conversions repository.ConversionUseCase
+	logger      *slog.Logger
+}
+
+func NewPostbackHandler(guard *Guard, conversions repository.ConversionUseCase, logger *slog.Logger) *PostbackHandler {
+	return &PostbackHandler{guard: guard, conversions: conversions, logger: logger}
+}
+
+func (h *PostbackHandler) Register(router *mux.Router) {
+	router.Handle("/postback", h.guard.Wrap("Postback", SecretKey, auth.ScopeConversionsWrite, h.serve)).
+		Methods(http.MethodGet)
+}
+
+func (h *PostbackHandler) serve(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var value float64
+	if raw := query.Get("value"); raw != "" {
+		var err error
+		if value, err = strconv.ParseFloat(raw, 64); err != nil {
+			http.Error(w, "invalid value", http.StatusBadRequest)
+			return
+		}
+	}
+
+	_, err := h.conversions.Register(r.Context(), &entity.Conversion{
+		ClickID:       query.Get("click_id"),
+		Value:         value,
+		Currency:      query.Get("currency"),
+		TransactionID: query.Get("transaction_id"),
+	})
+	// Advertisers retry postbacks they did not see succeed, so a repeated
+	// transaction is acknowledged rather than reported as a failure.
+	if err != nil && !errors.Is(err, entity.ErrDuplicateConversion) {
+		writeError(w, r, h.logger, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("OK"))
+}
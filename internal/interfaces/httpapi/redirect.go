@@ -0,0 +1,63 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"clicker/internal/auth"
+	"clicker/internal/clientip"
+	"clicker/internal/domain/entity"
+	"clicker/internal/domain/repository"
+	"github.com/gorilla/mux"
+)
+
+// RedirectHandler records a click and sends the clicker on to the banner's
+// target URL in one hop, so that no click is lost to a page unloading
+// before a separate counter call finishes. Like the pixel, it takes a
+// publishable API key with the clicks:write scope in the "key" query
+// parameter. The optional "token" and "sid" parameters carry the
+// click token and session id, and utm_* parameters fill the UTM macros.
+type RedirectHandler struct {
+	guard     *Guard
+	redirects repository.RedirectUseCase
+	logger    *slog.Logger
+}
+
+func NewRedirectHandler(guard *Guard, redirects repository.RedirectUseCase, logger *slog.Logger) *RedirectHandler {
+	return &RedirectHandler{guard: guard, redirects: redirects, logger: logger}
+}
+
+func (h *RedirectHandler) Register(router *mux.Router) {
+	router.Handle("/c/{banner_id:[0-9]+}", h.guard.Wrap("Redirect", PublishableKey, auth.ScopeClicksWrite, h.serve)).
+		Methods(http.MethodGet)
+}
+
+func (h *RedirectHandler) serve(w http.ResponseWriter, r *http.Request) {
+	bannerID, err := strconv.ParseInt(mux.Vars(r)["banner_id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid banner id", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	utm := make(map[string]string, len(entity.UTMParams))
+	for _, param := range entity.UTMParams {
+		utm[param] = query.Get(param)
+	}
+
+	target, err := h.redirects.Redirect(r.Context(), &entity.ClickRequest{
+		BannerID:  bannerID,
+		Token:     query.Get("token"),
+		SessionID: query.Get("sid"),
+		IP:        clientip.FromRequest(r),
+		UserAgent: r.UserAgent(),
+	}, utm)
+	if err != nil {
+		writeError(w, r, h.logger, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, target, http.StatusFound)
+}
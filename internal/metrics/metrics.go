@@ -0,0 +1,147 @@
+// Package metrics holds the Prometheus metrics of the service and serves
+// them on /metrics.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "clicker"
+
+// Registry holds every metric of the service together with the Go runtime
+// and process collectors.
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+var factory = promauto.With(Registry)
+
+// Batcher metrics, labelled with the name of the batcher ("clicks",
+// "impressions").
+var (
+	BatchQueueDepth = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "batch_queue_depth",
+		Help:      "Items waiting in the queue of a batcher.",
+	}, []string{"batcher"})
+
+	BatchFlushes = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "batch_flushes_total",
+		Help:      "Batches flushed, by what triggered the flush (size, timeout or drain).",
+	}, []string{"batcher", "reason"})
+
+	BatchSize = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "batch_size",
+		Help:      "Items per flushed batch.",
+		Buckets:   []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	}, []string{"batcher"})
+
+	BatchFlushDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "batch_flush_duration_seconds",
+		Help:      "Time taken to write a batch to the database.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"batcher"})
+
+	BatchFlushFailures = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "batch_flush_failures_total",
+		Help:      "Batches that could not be written.",
+	}, []string{"batcher"})
+
+	BatchRowsWritten = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "batch_rows_written_total",
+		Help:      "Items written to the database.",
+	}, []string{"batcher"})
+
+	BatchDropped = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "batch_dropped_total",
+		Help:      "Items lost because the batch holding them could not be written.",
+	}, []string{"batcher"})
+)
+
+// Handler serves the metrics of Registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// otherBanners is the banner_id label shared by the banners beyond the
+// tracked limit.
+const otherBanners = "other"
+
+var bannerClicks = factory.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "banner_clicks_total",
+	Help:      "Clicks by banner and outcome. Banners beyond the tracked limit are counted as banner_id=\"other\".",
+}, []string{"banner_id", "outcome"})
+
+var banners = struct {
+	sync.Mutex
+	limit   int
+	tracked map[int64]struct{}
+}{
+	limit:   100,
+	tracked: make(map[int64]struct{}),
+}
+
+// SetBannerLimit sets how many banners get a banner_id label of their own.
+// Banners are tracked in the order their first click arrives.
+func SetBannerLimit(limit int) {
+	banners.Lock()
+	defer banners.Unlock()
+	banners.limit = limit
+}
+
+// CountClick counts a click on a banner with its outcome, such as
+// "accepted", "invalid" or "daily_cap".
+func CountClick(bannerID int64, outcome string) {
+	bannerClicks.WithLabelValues(bannerLabel(bannerID), outcome).Inc()
+}
+
+func bannerLabel(bannerID int64) string {
+	banners.Lock()
+	defer banners.Unlock()
+
+	if _, ok := banners.tracked[bannerID]; !ok {
+		if len(banners.tracked) >= banners.limit {
+			return otherBanners
+		}
+		banners.tracked[bannerID] = struct{}{}
+	}
+	return strconv.FormatInt(bannerID, 10)
+}
+
+// gRPC server metrics, labelled like those of go-grpc-prometheus.
+var (
+	GRPCStarted = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_started_total",
+		Help: "RPCs started on the server.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+
+	GRPCHandled = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+
+	GRPCHandlingSeconds = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Response latency of RPCs handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+)
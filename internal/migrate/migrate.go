@@ -0,0 +1,324 @@
+// Package migrate applies versioned SQL migrations to the database and
+// records them in the schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// lockID is the key of the advisory lock held while migrating, so that
+// concurrent runners wait for each other.
+const lockID int64 = 0x636c69636b6572 // "clicker"
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// Migration is one version of the schema.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads the migrations in fsys, ordered by version.
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		raw, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(raw)
+		} else {
+			m.Down = string(raw)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Status describes a migration known to the files, the database or both.
+type Status struct {
+	Version int64
+	Name    string
+	// AppliedAt is nil for pending migrations.
+	AppliedAt *time.Time
+	// Missing is set for applied migrations without a file.
+	Missing bool
+}
+
+// Migrator applies migrations. Every migration runs in a transaction
+// together with its schema_migrations row, so a failed migration leaves
+// nothing behind.
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []*Migration
+	logger     *slog.Logger
+}
+
+func New(pool *pgxpool.Pool, migrations []*Migration, logger *slog.Logger) *Migrator {
+	return &Migrator{pool: pool, migrations: migrations, logger: logger}
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(conn *pgxpool.Conn, applied map[int64]time.Time) error {
+		_, up := plan(m.migrations, applied, math.MaxInt64)
+		return m.run(ctx, conn, nil, up)
+	})
+}
+
+// Down reverts the last n applied migrations.
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	return m.locked(ctx, func(conn *pgxpool.Conn, applied map[int64]time.Time) error {
+		return m.run(ctx, conn, latest(m.migrations, applied, n), nil)
+	})
+}
+
+// Goto applies or reverts migrations until exactly those up to version are
+// applied. Version 0 reverts everything.
+func (m *Migrator) Goto(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	return m.locked(ctx, func(conn *pgxpool.Conn, applied map[int64]time.Time) error {
+		down, up := plan(m.migrations, applied, version)
+		return m.run(ctx, conn, down, up)
+	})
+}
+
+// Force records the migrations up to version as applied, and those after
+// it as not applied, without running them. It adopts databases whose
+// schema was created by other means.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	return m.locked(ctx, func(conn *pgxpool.Conn, applied map[int64]time.Time) error {
+		return conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version > $1`, version); err != nil {
+				return fmt.Errorf("failed to force migration version: %w", err)
+			}
+			for _, migration := range m.migrations {
+				if _, ok := applied[migration.Version]; ok || migration.Version > version {
+					continue
+				}
+				if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+					migration.Version, migration.Name); err != nil {
+					return fmt.Errorf("failed to force migration version: %w", err)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// Status lists every migration with the time it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	var statuses []*Status
+	err := m.locked(ctx, func(conn *pgxpool.Conn, applied map[int64]time.Time) error {
+		for _, migration := range m.migrations {
+			status := &Status{Version: migration.Version, Name: migration.Name}
+			if at, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &at
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+		for version, at := range applied {
+			at := at
+			statuses = append(statuses, &Status{Version: version, AppliedAt: &at, Missing: true})
+		}
+		return nil
+	})
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
+
+// Pending reports how many migrations have not been applied.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// plan returns the applied migrations after version, newest first, which
+// have to be reverted, and the pending ones up to version, oldest first,
+// which have to be applied, for exactly those up to version to be applied.
+// Pending migrations older than applied ones are applied as well.
+func plan(migrations []*Migration, applied map[int64]time.Time, version int64) (down, up []*Migration) {
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if _, ok := applied[migrations[i].Version]; ok && migrations[i].Version > version {
+			down = append(down, migrations[i])
+		}
+	}
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+			up = append(up, migration)
+		}
+	}
+	return down, up
+}
+
+// latest returns the last n applied migrations, newest first.
+func latest(migrations []*Migration, applied map[int64]time.Time, n int) []*Migration {
+	var last []*Migration
+	for i := len(migrations) - 1; i >= 0 && len(last) < n; i-- {
+		if _, ok := applied[migrations[i].Version]; ok {
+			last = append(last, migrations[i])
+		}
+	}
+	return last
+}
+
+// run reverts down and then applies up, stopping at the first failure.
+func (m *Migrator) run(ctx context.Context, conn *pgxpool.Conn, down, up []*Migration) error {
+	for _, migration := range down {
+		if err := m.apply(ctx, conn, migration, false); err != nil {
+			return err
+		}
+	}
+	for _, migration := range up {
+		if err := m.apply(ctx, conn, migration, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+// locked runs fn on a connection holding the migration lock, with the
+// versions applied so far.
+func (m *Migrator) locked(ctx context.Context, fn func(*pgxpool.Conn, map[int64]time.Time) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID)
+
+	if _, err := conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return fn(conn, applied)
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, migration *Migration, up bool) error {
+	direction, sql := "up", migration.Up
+	if !up {
+		direction, sql = "down", migration.Down
+		if sql == "" {
+			return fmt.Errorf("%w: %d_%s", ErrNoDownMigration, migration.Version, migration.Name)
+		}
+	}
+	m.logger.InfoContext(ctx, "Applying migration", "version", migration.Version, "name", migration.Name, "direction", direction)
+
+	start := time.Now()
+	err := conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return err
+		}
+		var err error
+		if up {
+			_, err = tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, migration.Version, migration.Name)
+		} else {
+			_, err = tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to migrate %s %d_%s: %w", direction, migration.Version, migration.Name, err)
+	}
+	m.logger.InfoContext(ctx, "Applied migration", "version", migration.Version, "name", migration.Name,
+		"direction", direction, "duration", time.Since(start))
+	return nil
+}
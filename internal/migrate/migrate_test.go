@@ -0,0 +1,112 @@
+package migrate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func versions(migrations []*Migration) []int64 {
+	list := make([]int64, 0, len(migrations))
+	for _, m := range migrations {
+		list = append(list, m.Version)
+	}
+	return list
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"000010_add_index.up.sql":  {Data: []byte("CREATE INDEX i ON t (c);")},
+		"000002_create_t.up.sql":   {Data: []byte("CREATE TABLE t (c INT);")},
+		"000002_create_t.down.sql": {Data: []byte("DROP TABLE t;")},
+		"000001_init.up.sql":       {Data: []byte("SELECT 1;")},
+		"000001_init.down.sql":     {Data: []byte("SELECT 2;")},
+		"migrations.go":            {Data: []byte("package migrations")},
+		"000003_notes.txt":         {Data: []byte("not a migration")},
+	})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := versions(migrations); !slices.Equal(got, []int64{1, 2, 10}) {
+		t.Fatalf("Load versions = %v, want [1 2 10]", got)
+	}
+	if m := migrations[1]; m.Name != "create_t" || m.Up != "CREATE TABLE t (c INT);" || m.Down != "DROP TABLE t;" {
+		t.Errorf("migration 2 = %+v", m)
+	}
+	if migrations[2].Down != "" {
+		t.Errorf("migration 10 has down %q, want none", migrations[2].Down)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{"down without up", fstest.MapFS{
+			"000001_init.down.sql": {Data: []byte("SELECT 1;")},
+		}, "has no up file"},
+		{"two names", fstest.MapFS{
+			"000001_init.up.sql":  {Data: []byte("SELECT 1;")},
+			"000001_other.up.sql": {Data: []byte("SELECT 1;")},
+		}, "has two names"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.fsys); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	var migrations []*Migration
+	for _, v := range []int64{1, 2, 3, 4, 5} {
+		migrations = append(migrations, &Migration{Version: v})
+	}
+	applied := func(list ...int64) map[int64]time.Time {
+		m := make(map[int64]time.Time)
+		for _, v := range list {
+			m[v] = time.Time{}
+		}
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		applied  map[int64]time.Time
+		version  int64
+		wantDown []int64
+		wantUp   []int64
+	}{
+		{"fresh database", applied(), 5, nil, []int64{1, 2, 3, 4, 5}},
+		{"up to date", applied(1, 2, 3, 4, 5), 5, nil, nil},
+		{"pending tail", applied(1, 2), 5, nil, []int64{3, 4, 5}},
+		{"gap is filled", applied(1, 3), 5, nil, []int64{2, 4, 5}},
+		{"back down", applied(1, 2, 3, 4, 5), 2, []int64{5, 4, 3}, nil},
+		{"down to nothing", applied(1, 2, 3), 0, []int64{3, 2, 1}, nil},
+		{"down and up", applied(1, 3, 4), 3, []int64{4}, []int64{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			down, up := plan(migrations, tt.applied, tt.version)
+			if got := versions(down); !slices.Equal(got, tt.wantDown) {
+				t.Errorf("down = %v, want %v", got, tt.wantDown)
+			}
+			if got := versions(up); !slices.Equal(got, tt.wantUp) {
+				t.Errorf("up = %v, want %v", got, tt.wantUp)
+			}
+		})
+	}
+
+	if got := versions(latest(migrations, applied(1, 2, 4), 2)); !slices.Equal(got, []int64{4, 2}) {
+		t.Errorf("latest 2 = %v, want [4 2]", got)
+	}
+	if got := versions(latest(migrations, applied(1), 3)); !slices.Equal(got, []int64{1}) {
+		t.Errorf("latest 3 of one = %v, want [1]", got)
+	}
+}
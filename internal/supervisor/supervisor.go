@@ -0,0 +1,120 @@
+// Package supervisor runs the parts of the service as one group: when one of
+// them fails, all of them are stopped, in the reverse of the order they were
+// added in.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Component is one part of the group.
+type Component struct {
+	// Name identifies the component in logs and errors.
+	Name string
+	// Run does the work of the component and blocks until it is done. An
+	// error before the component is stopped stops the whole group; nil
+	// means the component finished and leaves the others running. Run may
+	// be nil for components that only need stopping.
+	Run func(ctx context.Context) error
+	// Stop ends what Run started, giving up when ctx is done. The context
+	// passed to Run is canceled before Stop is called, so components that
+	// run until their context is done need no Stop.
+	Stop func(ctx context.Context) error
+}
+
+// Group runs a set of components until the first one fails or the group is
+// asked to stop.
+type Group struct {
+	logger     *slog.Logger
+	timeout    time.Duration
+	components []Component
+}
+
+// New returns an empty group whose components have timeout, together, to
+// stop.
+func New(logger *slog.Logger, timeout time.Duration) *Group {
+	return &Group{logger: logger, timeout: timeout}
+}
+
+// Add appends a component. Components start in the order they are added and
+// stop in reverse, so a component should be added after those it depends on.
+func (g *Group) Add(c Component) {
+	g.components = append(g.components, c)
+}
+
+// Run starts every component and blocks until ctx is done or a component
+// fails. It then stops the components one by one, each only once the
+// previous one returned from Run, and returns the error that stopped the
+// group joined with those that occurred while stopping, or nil after a clean
+// stop.
+func (g *Group) Run(ctx context.Context) error {
+	type running struct {
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+
+	failed := make(chan error, len(g.components))
+	runs := make([]running, len(g.components))
+	for i, c := range g.components {
+		runCtx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		runs[i] = running{cancel: cancel, done: done}
+		if c.Run == nil {
+			close(done)
+			continue
+		}
+		go func(c Component) {
+			defer close(done)
+			if err := c.Run(runCtx); err != nil && runCtx.Err() == nil {
+				failed <- fmt.Errorf("%s: %w", c.Name, err)
+			}
+		}(c)
+	}
+
+	var errs []error
+	select {
+	case <-ctx.Done():
+		g.logger.Info("Shutting down")
+	case err := <-failed:
+		g.logger.Error("Shutting down after a failure", "error", err)
+		errs = append(errs, err)
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+	for i := len(g.components) - 1; i >= 0; i-- {
+		c := g.components[i]
+		start := time.Now()
+		runs[i].cancel()
+		if c.Stop != nil {
+			if err := c.Stop(stopCtx); err != nil {
+				errs = append(errs, fmt.Errorf("unable to stop %s: %w", c.Name, err))
+			}
+		}
+		select {
+		case <-runs[i].done:
+		case <-stopCtx.Done():
+			select {
+			case <-runs[i].done:
+			default:
+				errs = append(errs, fmt.Errorf("%s did not stop within %s", c.Name, g.timeout))
+				continue
+			}
+		}
+		g.logger.Debug("Stopped component", "component", c.Name, "duration", time.Since(start))
+	}
+
+	// Components that failed while the group was stopping.
+	for {
+		select {
+		case err := <-failed:
+			errs = append(errs, err)
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
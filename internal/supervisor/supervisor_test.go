@@ -0,0 +1,152 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// recorder notes the order in which components stop.
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) note(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.events, " ")
+}
+
+// blocking runs until its context is canceled and notes when it returns.
+func blocking(r *recorder, name string) Component {
+	return Component{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			r.note("exit " + name)
+			return ctx.Err()
+		},
+		Stop: func(ctx context.Context) error {
+			r.note("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestRunStopsInReverseOrder(t *testing.T) {
+	r := &recorder{}
+	g := New(discard, time.Second)
+	g.Add(blocking(r, "db"))
+	g.Add(Component{Name: "pool", Stop: func(ctx context.Context) error {
+		r.note("stop pool")
+		return nil
+	}})
+	g.Add(blocking(r, "server"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- g.Run(ctx) }()
+	cancel()
+
+	if err := <-errc; err != nil {
+		t.Fatalf("Run = %v, want nil after a clean stop", err)
+	}
+	// Each component has returned from Run before the next one is stopped.
+	want := "stop server exit server stop pool stop db exit db"
+	if got := r.String(); got != want {
+		t.Errorf("stop order = %q, want %q", got, want)
+	}
+}
+
+func TestRunStopsGroupOnFailure(t *testing.T) {
+	r := &recorder{}
+	failure := errors.New("listener closed")
+	g := New(discard, time.Second)
+	g.Add(blocking(r, "db"))
+	g.Add(Component{Name: "server", Run: func(ctx context.Context) error {
+		return failure
+	}})
+	g.Add(blocking(r, "worker"))
+
+	err := g.Run(context.Background())
+	if !errors.Is(err, failure) {
+		t.Fatalf("Run = %v, want the component's error", err)
+	}
+	if !strings.Contains(err.Error(), "server:") {
+		t.Errorf("Run = %q, want the failing component named", err)
+	}
+	if got, want := r.String(), "stop worker exit worker stop db exit db"; got != want {
+		t.Errorf("stop order = %q, want %q", got, want)
+	}
+}
+
+func TestRunFinishedComponentLeavesOthersRunning(t *testing.T) {
+	r := &recorder{}
+	g := New(discard, time.Second)
+	g.Add(Component{Name: "migrate", Run: func(ctx context.Context) error { return nil }})
+	g.Add(blocking(r, "server"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := g.Run(ctx); err != nil {
+		t.Fatalf("Run = %v, want nil", err)
+	}
+	if got, want := r.String(), "stop server exit server"; got != want {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestRunJoinsStopErrors(t *testing.T) {
+	failure := errors.New("boom")
+	stopFailure := errors.New("flush failed")
+	g := New(discard, time.Second)
+	g.Add(Component{Name: "batcher", Stop: func(ctx context.Context) error { return stopFailure }})
+	g.Add(Component{Name: "server", Run: func(ctx context.Context) error { return failure }})
+
+	err := g.Run(context.Background())
+	if !errors.Is(err, failure) || !errors.Is(err, stopFailure) {
+		t.Fatalf("Run = %v, want both errors", err)
+	}
+	if !strings.Contains(err.Error(), "unable to stop batcher") {
+		t.Errorf("Run = %q, want the component that failed to stop named", err)
+	}
+}
+
+func TestRunGivesUpAfterTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	r := &recorder{}
+	g := New(discard, 20*time.Millisecond)
+	g.Add(blocking(r, "db"))
+	g.Add(Component{Name: "stuck", Run: func(ctx context.Context) error {
+		<-release
+		return nil
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := g.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "stuck did not stop within") {
+		t.Fatalf("Run = %v, want a timeout for the stuck component", err)
+	}
+	// The remaining components are still asked to stop, though the shared
+	// timeout has run out for waiting on them.
+	if got := r.String(); !strings.HasPrefix(got, "stop db") {
+		t.Errorf("events = %q, want db stopped", got)
+	}
+}
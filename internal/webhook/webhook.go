@@ -0,0 +1,216 @@
+// Package webhook delivers alert notifications as signed JSON POST
+// requests.
+//
+// Every request carries the headers
+//
+//	X-Clicker-Delivery:  id of the notification, the same on every retry
+//	X-Clicker-Timestamp: unix time the request was signed at
+//	X-Clicker-Signature: sha256=<hex HMAC-SHA256 of "<timestamp>.<body>">
+//
+// so that receivers can verify the sender and reject replayed requests.
+//
+// Webhook URLs are chosen by tenants, so requests are never sent to
+// loopback, private, link-local or other internal addresses. The check runs
+// when a URL is accepted and again on every connection, so that a name that
+// later resolves to an internal address is refused as well.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"clicker/internal/domain/entity"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	firstBackoff   = time.Second
+	maxBackoff     = time.Minute
+	dialTimeout    = 5 * time.Second
+)
+
+// ErrForbiddenAddress is returned for webhooks on internal addresses.
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// blockedNets are ranges that are not covered by the net.IP predicates used
+// in allowed.
+var blockedNets = []*net.IPNet{
+	mustCIDR("0.0.0.0/8"),
+	mustCIDR("100.64.0.0/10"),
+	mustCIDR("192.0.0.0/24"),
+	mustCIDR("198.18.0.0/15"),
+	mustCIDR("240.0.0.0/4"),
+	mustCIDR("64:ff9b::/96"),
+}
+
+// Sender posts notifications, retrying failed deliveries with exponential
+// backoff. Requests are left unsigned without a secret.
+type Sender struct {
+	client  *http.Client
+	secret  []byte
+	retries int
+}
+
+func NewSender(secret []byte, retries int) *Sender {
+	dialer := &net.Dialer{Timeout: dialTimeout, Control: dialControl}
+	return &Sender{
+		client: &http.Client{
+			Timeout: requestTimeout,
+			// No proxy: the address check has to see the receiver.
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: dialTimeout,
+			},
+		},
+		secret:  secret,
+		retries: retries,
+	}
+}
+
+// CheckURL accepts absolute http and https URLs whose host only resolves to
+// public addresses.
+func (s *Sender) CheckURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("webhook url must be an absolute http or https url")
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !allowed(ip) {
+			return ErrForbiddenAddress
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("unable to resolve webhook host %q", host)
+	}
+	for _, addr := range addrs {
+		if !allowed(addr.IP) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+func (s *Sender) Notify(ctx context.Context, url string, notification *entity.AlertNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	delivery := make([]byte, 16)
+	if _, err := rand.Read(delivery); err != nil {
+		return fmt.Errorf("failed to generate delivery id: %w", err)
+	}
+
+	backoff := firstBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(ctx, url, hex.EncodeToString(delivery), body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.retries {
+			return fmt.Errorf("failed to deliver webhook after %d attempts: %w", attempt+1, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// post sends one request and reports whether a failure is worth retrying.
+func (s *Sender) post(ctx context.Context, url, delivery string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Clicker-Delivery", delivery)
+	req.Header.Set("X-Clicker-Timestamp", timestamp)
+	if len(s.secret) > 0 {
+		req.Header.Set("X-Clicker-Signature", "sha256="+Sign(s.secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook answered %s", resp.Status)
+	default:
+		return false, fmt.Errorf("webhook answered %s", resp.Status)
+	}
+}
+
+// Sign returns the hex encoded signature of a request body signed at the
+// given unix timestamp.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// dialControl refuses connections to addresses CheckURL would refuse. It
+// runs after name resolution, on the address actually dialed.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// allowed reports whether ip is a public unicast address.
+func allowed(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range blockedNets {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"clicker/internal/domain/entity"
+)
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		forbidden bool
+		invalid   bool
+	}{
+		{"https://203.0.113.10/hook", false, false},
+		{"http://[2001:db8::1]:8080/hook", false, false},
+		{"http://127.0.0.1/hook", true, false},
+		{"http://[::1]/hook", true, false},
+		{"http://10.1.2.3/hook", true, false},
+		{"http://172.16.0.1/hook", true, false},
+		{"http://192.168.1.1/hook", true, false},
+		{"http://169.254.169.254/latest/meta-data", true, false},
+		{"http://[fd00:ec2::254]/", true, false},
+		{"http://[fe80::1]/", true, false},
+		{"http://0.0.0.0/", true, false},
+		{"http://100.64.0.1/", true, false},
+		{"http://[::ffff:127.0.0.1]/", true, false},
+		{"ftp://203.0.113.10/hook", false, true},
+		{"/relative", false, true},
+		{"https://", false, true},
+	}
+	s := NewSender(nil, 0)
+	for _, tt := range tests {
+		err := s.CheckURL(context.Background(), tt.url)
+		switch {
+		case tt.forbidden && !errors.Is(err, ErrForbiddenAddress):
+			t.Errorf("CheckURL(%q) = %v, want ErrForbiddenAddress", tt.url, err)
+		case tt.invalid && (err == nil || errors.Is(err, ErrForbiddenAddress)):
+			t.Errorf("CheckURL(%q) = %v, want invalid url error", tt.url, err)
+		case !tt.forbidden && !tt.invalid && err != nil:
+			t.Errorf("CheckURL(%q) = %v, want nil", tt.url, err)
+		}
+	}
+}
+
+func TestNotifyRefusesInternalAddressAtDial(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	err := NewSender(nil, 0).Notify(context.Background(), server.URL, &entity.AlertNotification{})
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("Notify = %v, want ErrForbiddenAddress", err)
+	}
+	if called {
+		t.Fatal("webhook on loopback was called")
+	}
+}
+
+func TestSign(t *testing.T) {
+	got := Sign([]byte("secret"), "1700000000", []byte(`{"a":1}`))
+	if got != Sign([]byte("secret"), "1700000000", []byte(`{"a":1}`)) {
+		t.Fatal("Sign is not deterministic")
+	}
+	if got == Sign([]byte("other"), "1700000000", []byte(`{"a":1}`)) {
+		t.Fatal("signature does not depend on the secret")
+	}
+	if got == Sign([]byte("secret"), "1700000001", []byte(`{"a":1}`)) {
+		t.Fatal("signature does not depend on the timestamp")
+	}
+}
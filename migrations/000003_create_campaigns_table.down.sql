@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS campaign_banners CASCADE;
+DROP TABLE IF EXISTS campaigns CASCADE;
@@ -0,0 +1,21 @@
+CREATE TABLE campaigns (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE campaign_banners (
+    campaign_id INTEGER NOT NULL,
+    banner_id INTEGER NOT NULL,
+    PRIMARY KEY (campaign_id, banner_id),
+    CONSTRAINT fk_campaign
+        FOREIGN KEY (campaign_id)
+        REFERENCES campaigns(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_banner
+        FOREIGN KEY (banner_id)
+        REFERENCES banners(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_campaign_banners_banner ON campaign_banners(banner_id);
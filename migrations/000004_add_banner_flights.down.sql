@@ -0,0 +1,9 @@
+ALTER TABLE clicks
+    DROP COLUMN IF EXISTS out_of_flight;
+
+DROP TABLE IF EXISTS banner_dayparts CASCADE;
+
+ALTER TABLE banners
+    DROP COLUMN IF EXISTS starts_at,
+    DROP COLUMN IF EXISTS ends_at,
+    DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,23 @@
+ALTER TABLE banners
+    ADD COLUMN starts_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN ends_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE banner_dayparts (
+    id SERIAL PRIMARY KEY,
+    banner_id INTEGER NOT NULL,
+    weekdays SMALLINT[] NOT NULL DEFAULT '{}',
+    start_minute SMALLINT NOT NULL,
+    end_minute SMALLINT NOT NULL,
+    CONSTRAINT chk_daypart_range
+        CHECK (start_minute >= 0 AND end_minute <= 1440 AND start_minute < end_minute),
+    CONSTRAINT fk_banner
+        FOREIGN KEY (banner_id)
+        REFERENCES banners(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_banner_dayparts_banner ON banner_dayparts(banner_id);
+
+ALTER TABLE clicks
+    ADD COLUMN out_of_flight BOOLEAN NOT NULL DEFAULT false;
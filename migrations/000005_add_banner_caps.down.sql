@@ -0,0 +1,5 @@
+ALTER TABLE banners
+    DROP CONSTRAINT IF EXISTS chk_daily_click_cap,
+    DROP CONSTRAINT IF EXISTS chk_lifetime_click_cap,
+    DROP COLUMN IF EXISTS daily_click_cap,
+    DROP COLUMN IF EXISTS lifetime_click_cap;
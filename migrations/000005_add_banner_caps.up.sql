@@ -0,0 +1,5 @@
+ALTER TABLE banners
+    ADD COLUMN daily_click_cap BIGINT,
+    ADD COLUMN lifetime_click_cap BIGINT,
+    ADD CONSTRAINT chk_daily_click_cap CHECK (daily_click_cap > 0),
+    ADD CONSTRAINT chk_lifetime_click_cap CHECK (lifetime_click_cap > 0);
@@ -0,0 +1,5 @@
+ALTER TABLE campaigns DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE banners DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenant_tokens CASCADE;
+DROP TABLE IF EXISTS tenants CASCADE;
@@ -0,0 +1,41 @@
+CREATE TABLE tenants (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE tenant_tokens (
+    token_hash BYTEA PRIMARY KEY,
+    tenant_id INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_tenant
+        FOREIGN KEY (tenant_id)
+        REFERENCES tenants(id)
+        ON DELETE CASCADE
+);
+
+-- Everything that existed before tenants were introduced belongs to the
+-- default tenant.
+INSERT INTO tenants (id, name) VALUES (1, 'Default');
+SELECT setval('tenants_id_seq', (SELECT MAX(id) FROM tenants));
+
+ALTER TABLE banners ADD COLUMN tenant_id INTEGER;
+UPDATE banners SET tenant_id = 1;
+ALTER TABLE banners
+    ALTER COLUMN tenant_id SET NOT NULL,
+    ADD CONSTRAINT fk_tenant
+        FOREIGN KEY (tenant_id)
+        REFERENCES tenants(id)
+        ON DELETE CASCADE;
+
+ALTER TABLE campaigns ADD COLUMN tenant_id INTEGER;
+UPDATE campaigns SET tenant_id = 1;
+ALTER TABLE campaigns
+    ALTER COLUMN tenant_id SET NOT NULL,
+    ADD CONSTRAINT fk_tenant
+        FOREIGN KEY (tenant_id)
+        REFERENCES tenants(id)
+        ON DELETE CASCADE;
+
+CREATE INDEX idx_banners_tenant ON banners(tenant_id, id);
+CREATE INDEX idx_campaigns_tenant ON campaigns(tenant_id, id);
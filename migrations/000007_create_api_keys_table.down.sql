@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS tenant_tokens CASCADE;
+CREATE TABLE tenant_tokens (
+    token_hash BYTEA PRIMARY KEY,
+    tenant_id INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_tenant
+        FOREIGN KEY (tenant_id)
+        REFERENCES tenants(id)
+        ON DELETE CASCADE
+);
+
+INSERT INTO tenant_tokens (token_hash, tenant_id, created_at)
+SELECT key_hash, tenant_id, created_at
+FROM api_keys
+WHERE revoked_at IS NULL;
+
+DROP TABLE IF EXISTS api_keys CASCADE;
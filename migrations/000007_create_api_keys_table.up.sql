@@ -0,0 +1,30 @@
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash BYTEA NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_tenant
+        FOREIGN KEY (tenant_id)
+        REFERENCES tenants(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_api_keys_tenant ON api_keys(tenant_id);
+
+-- Tenant tokens become API keys with every scope.
+INSERT INTO api_keys (tenant_id, name, key_prefix, key_hash, scopes, created_at)
+SELECT
+    tenant_id,
+    'migrated tenant token',
+    '',
+    token_hash,
+    ARRAY['clicks:write', 'stats:read', 'campaigns:read', 'campaigns:write',
+          'banners:read', 'banners:write', 'keys:manage'],
+    created_at
+FROM tenant_tokens;
+
+DROP TABLE IF EXISTS tenant_tokens CASCADE;
@@ -0,0 +1,12 @@
+CREATE TABLE invalid_clicks (
+    id SERIAL PRIMARY KEY,
+    banner_id INTEGER NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    reason VARCHAR(32) NOT NULL,
+    CONSTRAINT fk_banner
+        FOREIGN KEY (banner_id)
+        REFERENCES banners(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_invalid_clicks_banner_timestamp ON invalid_clicks(banner_id, timestamp);
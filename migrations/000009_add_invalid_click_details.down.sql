@@ -0,0 +1,3 @@
+ALTER TABLE invalid_clicks
+    DROP COLUMN IF EXISTS ip,
+    DROP COLUMN IF EXISTS user_agent;
@@ -0,0 +1,3 @@
+ALTER TABLE invalid_clicks
+    ADD COLUMN ip VARCHAR(64),
+    ADD COLUMN user_agent TEXT;
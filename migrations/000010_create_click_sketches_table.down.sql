@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS banner_sketches CASCADE;
+DROP TABLE IF EXISTS click_sketches CASCADE;
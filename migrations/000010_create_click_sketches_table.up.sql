@@ -0,0 +1,21 @@
+-- HyperLogLog sketches of click fingerprints: one per banner and hour, plus
+-- one per banner covering its whole lifetime.
+CREATE TABLE click_sketches (
+    banner_id INTEGER NOT NULL,
+    bucket TIMESTAMP WITH TIME ZONE NOT NULL,
+    sketch BYTEA NOT NULL,
+    PRIMARY KEY (banner_id, bucket),
+    CONSTRAINT fk_banner
+        FOREIGN KEY (banner_id)
+        REFERENCES banners(id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE banner_sketches (
+    banner_id INTEGER PRIMARY KEY,
+    sketch BYTEA NOT NULL,
+    CONSTRAINT fk_banner
+        FOREIGN KEY (banner_id)
+        REFERENCES banners(id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+CREATE TABLE impressions (
+    id SERIAL PRIMARY KEY,
+    banner_id INTEGER NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    count INTEGER DEFAULT 1,
+    CONSTRAINT fk_banner
+        FOREIGN KEY (banner_id)
+        REFERENCES banners(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_impressions_banner_timestamp ON impressions(banner_id, timestamp);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_clicks_click_id;
+
+ALTER TABLE clicks
+    DROP COLUMN IF EXISTS click_id;
+
+ALTER TABLE banners
+    DROP COLUMN IF EXISTS target_url;
@@ -0,0 +1,7 @@
+ALTER TABLE banners
+    ADD COLUMN target_url TEXT;
+
+ALTER TABLE clicks
+    ADD COLUMN click_id VARCHAR(32);
+
+CREATE UNIQUE INDEX idx_clicks_click_id ON clicks(click_id);
@@ -0,0 +1,20 @@
+CREATE TABLE conversions (
+    id SERIAL PRIMARY KEY,
+    click_id VARCHAR(32) NOT NULL,
+    banner_id INTEGER NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    value NUMERIC(18, 4) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    transaction_id VARCHAR(255),
+    CONSTRAINT fk_click
+        FOREIGN KEY (click_id)
+        REFERENCES clicks(click_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_banner
+        FOREIGN KEY (banner_id)
+        REFERENCES banners(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_conversions_click_id ON conversions(click_id);
+CREATE UNIQUE INDEX idx_conversions_transaction ON conversions(banner_id, transaction_id);
@@ -0,0 +1,27 @@
+CREATE TABLE alert_rules (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL,
+    banner_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    operator VARCHAR(16) NOT NULL DEFAULT '',
+    threshold DOUBLE PRECISION NOT NULL DEFAULT 0,
+    window_seconds INTEGER NOT NULL,
+    webhook_url TEXT NOT NULL,
+    state VARCHAR(16) NOT NULL DEFAULT 'ok',
+    value DOUBLE PRECISION,
+    state_changed_at TIMESTAMP WITH TIME ZONE,
+    evaluated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_tenant
+        FOREIGN KEY (tenant_id)
+        REFERENCES tenants(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_banner
+        FOREIGN KEY (banner_id)
+        REFERENCES banners(id)
+        ON DELETE CASCADE,
+    CONSTRAINT chk_window_seconds CHECK (window_seconds > 0)
+);
+
+CREATE INDEX idx_alert_rules_tenant ON alert_rules(tenant_id);
@@ -0,0 +1,2 @@
+-- The removed scope is not restored: it no longer grants anything.
+SELECT 1;
@@ -0,0 +1,3 @@
+-- Service-wide settings moved to the operator token; no API key may hold
+-- the scope that used to guard them.
+UPDATE api_keys SET scopes = array_remove(scopes, 'settings:manage');
@@ -0,0 +1,4 @@
+-- Publishable keys are embedded in pages for the pixel and redirect
+-- endpoints and refused everywhere else.
+ALTER TABLE api_keys
+    ADD COLUMN publishable BOOLEAN NOT NULL DEFAULT FALSE;
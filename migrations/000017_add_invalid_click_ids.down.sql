@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_invalid_clicks_click_id;
+
+ALTER TABLE invalid_clicks
+    DROP COLUMN IF EXISTS click_id;
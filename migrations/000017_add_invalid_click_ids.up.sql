@@ -0,0 +1,6 @@
+-- Filtered clicks are answered like counted ones, click id included, so
+-- the id is kept for conversions to recognise and refuse them.
+ALTER TABLE invalid_clicks
+    ADD COLUMN click_id VARCHAR(32);
+
+CREATE UNIQUE INDEX idx_invalid_clicks_click_id ON invalid_clicks(click_id);
@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL migrations of the database schema.
+package migrations
+
+import "embed"
+
+// FS holds the migrations as "<version>_<name>.up.sql" and
+// "<version>_<name>.down.sql" files.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v5.27.1
+// source: banner.proto
+
+package banner
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	BannerService_GetBanner_FullMethodName          = "/clicker.BannerService/GetBanner"
+	BannerService_SetBannerFlight_FullMethodName    = "/clicker.BannerService/SetBannerFlight"
+	BannerService_SetBannerCaps_FullMethodName      = "/clicker.BannerService/SetBannerCaps"
+	BannerService_SetBannerTargetURL_FullMethodName = "/clicker.BannerService/SetBannerTargetURL"
+	BannerService_WatchCapEvents_FullMethodName     = "/clicker.BannerService/WatchCapEvents"
+)
+
+// BannerServiceClient is the client API for BannerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BannerServiceClient interface {
+	GetBanner(ctx context.Context, in *GetBannerRequest, opts ...grpc.CallOption) (*Banner, error)
+	SetBannerFlight(ctx context.Context, in *SetBannerFlightRequest, opts ...grpc.CallOption) (*Banner, error)
+	SetBannerCaps(ctx context.Context, in *SetBannerCapsRequest, opts ...grpc.CallOption) (*Banner, error)
+	// Sets the landing page clicks on the banner are redirected to. An empty
+	// target_url clears it.
+	SetBannerTargetURL(ctx context.Context, in *SetBannerTargetURLRequest, opts ...grpc.CallOption) (*Banner, error)
+	// Streams an event every time a banner exhausts one of its click caps.
+	WatchCapEvents(ctx context.Context, in *WatchCapEventsRequest, opts ...grpc.CallOption) (BannerService_WatchCapEventsClient, error)
+}
+
+type bannerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBannerServiceClient(cc grpc.ClientConnInterface) BannerServiceClient {
+	return &bannerServiceClient{cc}
+}
+
+func (c *bannerServiceClient) GetBanner(ctx context.Context, in *GetBannerRequest, opts ...grpc.CallOption) (*Banner, error) {
+	out := new(Banner)
+	err := c.cc.Invoke(ctx, BannerService_GetBanner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) SetBannerFlight(ctx context.Context, in *SetBannerFlightRequest, opts ...grpc.CallOption) (*Banner, error) {
+	out := new(Banner)
+	err := c.cc.Invoke(ctx, BannerService_SetBannerFlight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) SetBannerCaps(ctx context.Context, in *SetBannerCapsRequest, opts ...grpc.CallOption) (*Banner, error) {
+	out := new(Banner)
+	err := c.cc.Invoke(ctx, BannerService_SetBannerCaps_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) SetBannerTargetURL(ctx context.Context, in *SetBannerTargetURLRequest, opts ...grpc.CallOption) (*Banner, error) {
+	out := new(Banner)
+	err := c.cc.Invoke(ctx, BannerService_SetBannerTargetURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) WatchCapEvents(ctx context.Context, in *WatchCapEventsRequest, opts ...grpc.CallOption) (BannerService_WatchCapEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BannerService_ServiceDesc.Streams[0], BannerService_WatchCapEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bannerServiceWatchCapEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BannerService_WatchCapEventsClient interface {
+	Recv() (*CapEvent, error)
+	grpc.ClientStream
+}
+
+type bannerServiceWatchCapEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bannerServiceWatchCapEventsClient) Recv() (*CapEvent, error) {
+	m := new(CapEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BannerServiceServer is the server API for BannerService service.
+// All implementations must embed UnimplementedBannerServiceServer
+// for forward compatibility
+type BannerServiceServer interface {
+	GetBanner(context.Context, *GetBannerRequest) (*Banner, error)
+	SetBannerFlight(context.Context, *SetBannerFlightRequest) (*Banner, error)
+	SetBannerCaps(context.Context, *SetBannerCapsRequest) (*Banner, error)
+	// Sets the landing page clicks on the banner are redirected to. An empty
+	// target_url clears it.
+	SetBannerTargetURL(context.Context, *SetBannerTargetURLRequest) (*Banner, error)
+	// Streams an event every time a banner exhausts one of its click caps.
+	WatchCapEvents(*WatchCapEventsRequest, BannerService_WatchCapEventsServer) error
+	mustEmbedUnimplementedBannerServiceServer()
+}
+
+// UnimplementedBannerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBannerServiceServer struct {
+}
+
+func (UnimplementedBannerServiceServer) GetBanner(context.Context, *GetBannerRequest) (*Banner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) SetBannerFlight(context.Context, *SetBannerFlightRequest) (*Banner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBannerFlight not implemented")
+}
+func (UnimplementedBannerServiceServer) SetBannerCaps(context.Context, *SetBannerCapsRequest) (*Banner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBannerCaps not implemented")
+}
+func (UnimplementedBannerServiceServer) SetBannerTargetURL(context.Context, *SetBannerTargetURLRequest) (*Banner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBannerTargetURL not implemented")
+}
+func (UnimplementedBannerServiceServer) WatchCapEvents(*WatchCapEventsRequest, BannerService_WatchCapEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCapEvents not implemented")
+}
+func (UnimplementedBannerServiceServer) mustEmbedUnimplementedBannerServiceServer() {}
+
+// UnsafeBannerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BannerServiceServer will
+// result in compilation errors.
+type UnsafeBannerServiceServer interface {
+	mustEmbedUnimplementedBannerServiceServer()
+}
+
+func RegisterBannerServiceServer(s grpc.ServiceRegistrar, srv BannerServiceServer) {
+	s.RegisterService(&BannerService_ServiceDesc, srv)
+}
+
+func _BannerService_GetBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).GetBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_GetBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).GetBanner(ctx, req.(*GetBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_SetBannerFlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBannerFlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).SetBannerFlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_SetBannerFlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).SetBannerFlight(ctx, req.(*SetBannerFlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_SetBannerCaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBannerCapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).SetBannerCaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_SetBannerCaps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).SetBannerCaps(ctx, req.(*SetBannerCapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_SetBannerTargetURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBannerTargetURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).SetBannerTargetURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_SetBannerTargetURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).SetBannerTargetURL(ctx, req.(*SetBannerTargetURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_WatchCapEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCapEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BannerServiceServer).WatchCapEvents(m, &bannerServiceWatchCapEventsServer{stream})
+}
+
+type BannerService_WatchCapEventsServer interface {
+	Send(*CapEvent) error
+	grpc.ServerStream
+}
+
+type bannerServiceWatchCapEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bannerServiceWatchCapEventsServer) Send(m *CapEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// BannerService_ServiceDesc is the grpc.ServiceDesc for BannerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BannerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "clicker.BannerService",
+	HandlerType: (*BannerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBanner",
+			Handler:    _BannerService_GetBanner_Handler,
+		},
+		{
+			MethodName: "SetBannerFlight",
+			Handler:    _BannerService_SetBannerFlight_Handler,
+		},
+		{
+			MethodName: "SetBannerCaps",
+			Handler:    _BannerService_SetBannerCaps_Handler,
+		},
+		{
+			MethodName: "SetBannerTargetURL",
+			Handler:    _BannerService_SetBannerTargetURL_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCapEvents",
+			Handler:       _BannerService_WatchCapEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "banner.proto",
+}
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v5.27.1
+// source: impression.proto
+
+package impression
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ImpressionService_Impression_FullMethodName = "/clicker.ImpressionService/Impression"
+)
+
+// ImpressionServiceClient is the client API for ImpressionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ImpressionServiceClient interface {
+	Impression(ctx context.Context, in *ImpressionRequest, opts ...grpc.CallOption) (*ImpressionResponse, error)
+}
+
+type impressionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewImpressionServiceClient(cc grpc.ClientConnInterface) ImpressionServiceClient {
+	return &impressionServiceClient{cc}
+}
+
+func (c *impressionServiceClient) Impression(ctx context.Context, in *ImpressionRequest, opts ...grpc.CallOption) (*ImpressionResponse, error) {
+	out := new(ImpressionResponse)
+	err := c.cc.Invoke(ctx, ImpressionService_Impression_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ImpressionServiceServer is the server API for ImpressionService service.
+// All implementations must embed UnimplementedImpressionServiceServer
+// for forward compatibility
+type ImpressionServiceServer interface {
+	Impression(context.Context, *ImpressionRequest) (*ImpressionResponse, error)
+	mustEmbedUnimplementedImpressionServiceServer()
+}
+
+// UnimplementedImpressionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedImpressionServiceServer struct {
+}
+
+func (UnimplementedImpressionServiceServer) Impression(context.Context, *ImpressionRequest) (*ImpressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Impression not implemented")
+}
+func (UnimplementedImpressionServiceServer) mustEmbedUnimplementedImpressionServiceServer() {}
+
+// UnsafeImpressionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ImpressionServiceServer will
+// result in compilation errors.
+type UnsafeImpressionServiceServer interface {
+	mustEmbedUnimplementedImpressionServiceServer()
+}
+
+func RegisterImpressionServiceServer(s grpc.ServiceRegistrar, srv ImpressionServiceServer) {
+	s.RegisterService(&ImpressionService_ServiceDesc, srv)
+}
+
+func _ImpressionService_Impression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImpressionServiceServer).Impression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImpressionService_Impression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImpressionServiceServer).Impression(ctx, req.(*ImpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ImpressionService_ServiceDesc is the grpc.ServiceDesc for ImpressionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ImpressionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "clicker.ImpressionService",
+	HandlerType: (*ImpressionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Impression",
+			Handler:    _ImpressionService_Impression_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "impression.proto",
+}
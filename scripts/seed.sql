@@ -1,33 +1,48 @@
 TRUNCATE TABLE banners CASCADE;
+TRUNCATE TABLE api_keys;
 
-INSERT INTO banners (id, name) VALUES 
-(1, 'Banner #1 - Homepage Hero'),
-(2, 'Banner #2 - Sidebar Promo'),
-(3, 'Banner #3 - Footer Ad'),
-(4, 'Banner #4 - Product Page Top'),
-(5, 'Banner #5 - Category Showcase'),
-(6, 'Banner #6 - Mobile App Promo'),
-(7, 'Banner #7 - Newsletter Signup'),
-(8, 'Banner #8 - Special Offer'),
-(9, 'Banner #9 - Holiday Campaign'),
-(10, 'Banner #10 - Flash Sale'),
-(11, 'Banner #11 - Blog Sidebar'),
-(12, 'Banner #12 - Search Results'),
-(13, 'Banner #13 - Account Page'),
-(14, 'Banner #14 - Checkout Upsell'),
-(15, 'Banner #15 - Social Media'),
-(16, 'Banner #16 - Email Campaign'),
-(17, 'Banner #17 - Partner Promo'),
-(18, 'Banner #18 - Seasonal Deal'),
-(19, 'Banner #19 - Limited Time'),
-(20, 'Banner #20 - Member Exclusive');
+-- Development key for the default tenant with every scope:
+-- "X-API-Key: dev-token" or "Authorization: Bearer dev-token".
+INSERT INTO api_keys (tenant_id, name, key_prefix, key_hash, scopes) VALUES
+(1, 'development', 'dev-toke', sha256('dev-token'::bytea),
+ ARRAY['clicks:write', 'stats:read', 'campaigns:read', 'campaigns:write',
+       'banners:read', 'banners:write', 'keys:manage', 'click-tokens:issue',
+       'impressions:write', 'conversions:write', 'alerts:manage']);
+
+-- Publishable development key for the pixel and redirect: "?key=dev-pixel".
+INSERT INTO api_keys (tenant_id, name, key_prefix, key_hash, scopes, publishable) VALUES
+(1, 'development pixel', 'dev-pixe', sha256('dev-pixel'::bytea),
+ ARRAY['clicks:write', 'impressions:write'], TRUE);
+
+INSERT INTO banners (id, tenant_id, name) VALUES 
+(1, 1, 'Banner #1 - Homepage Hero'),
+(2, 1, 'Banner #2 - Sidebar Promo'),
+(3, 1, 'Banner #3 - Footer Ad'),
+(4, 1, 'Banner #4 - Product Page Top'),
+(5, 1, 'Banner #5 - Category Showcase'),
+(6, 1, 'Banner #6 - Mobile App Promo'),
+(7, 1, 'Banner #7 - Newsletter Signup'),
+(8, 1, 'Banner #8 - Special Offer'),
+(9, 1, 'Banner #9 - Holiday Campaign'),
+(10, 1, 'Banner #10 - Flash Sale'),
+(11, 1, 'Banner #11 - Blog Sidebar'),
+(12, 1, 'Banner #12 - Search Results'),
+(13, 1, 'Banner #13 - Account Page'),
+(14, 1, 'Banner #14 - Checkout Upsell'),
+(15, 1, 'Banner #15 - Social Media'),
+(16, 1, 'Banner #16 - Email Campaign'),
+(17, 1, 'Banner #17 - Partner Promo'),
+(18, 1, 'Banner #18 - Seasonal Deal'),
+(19, 1, 'Banner #19 - Limited Time'),
+(20, 1, 'Banner #20 - Member Exclusive');
 
 WITH series AS (
   SELECT generate_series(21, 100) as id
 )
-INSERT INTO banners (id, name)
+INSERT INTO banners (id, tenant_id, name)
 SELECT
     id,
+    1 as tenant_id,
     'Banner #' || id::text || ' - ' ||
     CASE (id % 5)
         WHEN 0 THEN 'Premium Ad'